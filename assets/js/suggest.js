@@ -0,0 +1,43 @@
+// ============================================================
+// AUTOCOMPLÉTION DU CHAMP DE RECHERCHE
+// ============================================================
+// Les champs portant l'attribut data-suggest reçoivent une liste de noms
+// proposés par /api/suggest pendant la saisie.
+document.querySelectorAll("input[data-suggest]").forEach(function (input, i) {
+    var list = document.createElement("datalist");
+    list.id = "digimon-suggestions-" + i;
+    input.setAttribute("list", list.id);
+    input.setAttribute("autocomplete", "off");
+    input.after(list);
+
+    var timer = null;
+    var pending = null;
+
+    input.addEventListener("input", function () {
+        clearTimeout(timer);
+        var q = input.value.trim();
+        if (q === "") {
+            list.replaceChildren();
+            return;
+        }
+
+        // Attend une courte pause dans la saisie et annule la requête précédente
+        timer = setTimeout(function () {
+            if (pending) {
+                pending.abort();
+            }
+            pending = new AbortController();
+
+            fetch("/api/suggest?q=" + encodeURIComponent(q), { signal: pending.signal })
+                .then(function (response) { return response.ok ? response.json() : { data: [] }; })
+                .then(function (body) {
+                    list.replaceChildren.apply(list, (body.data || []).map(function (digimon) {
+                        var option = document.createElement("option");
+                        option.value = digimon.name;
+                        return option;
+                    }));
+                })
+                .catch(function () { /* requête annulée ou API indisponible */ });
+        }, 150);
+    });
+});
@@ -0,0 +1,148 @@
+// Package battle contient le moteur de combat entre deux Digimons : règles
+// (triangle des attributs, paliers de niveau, compétences) et simulation
+// déterministe des tours. Il ne dépend ni de l'API ni des services.
+package battle
+
+import "strings"
+
+// Attributs du triangle d'avantage
+const (
+	Vaccine = "Vaccine"
+	Data    = "Data"
+	Virus   = "Virus"
+	Free    = "Free"
+)
+
+// Multiplicateurs de dégâts selon le triangle des attributs
+const (
+	AdvantageMultiplier    = 1.5
+	DisadvantageMultiplier = 0.75
+	NeutralMultiplier      = 1.0
+)
+
+// beats indique quel attribut chaque attribut domine :
+// Vaccine > Virus > Data > Vaccine ; Free est neutre
+var beats = map[string]string{
+	Vaccine: Virus,
+	Virus:   Data,
+	Data:    Vaccine,
+}
+
+// levelTiers donne le palier de puissance de chaque niveau
+var levelTiers = map[string]int{
+	"fresh":       1,
+	"in-training": 2,
+	"rookie":      3,
+	"armor":       4,
+	"champion":    4,
+	"hybrid":      4,
+	"ultimate":    5,
+	"mega":        6,
+	"ultra":       7,
+}
+
+// defaultTier est le palier des niveaux inconnus ou absents
+const defaultTier = 3
+
+// maxCountedSkills borne le bonus apporté par le nombre de compétences
+const maxCountedSkills = 10
+
+// ============================================================
+// COMBATTANTS
+// ============================================================
+
+// Fighter décrit un Digimon engagé dans un combat
+type Fighter struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Image     string   `json:"image"`
+	Attribute string   `json:"attribute"` // Vaccine, Data, Virus, Free (vide = neutre)
+	Level     string   `json:"level"`
+	Skills    []string `json:"skills"`
+}
+
+// Stats sont les caractéristiques de combat calculées d'un combattant
+type Stats struct {
+	Tier    int `json:"tier"`
+	HP      int `json:"hp"`
+	Attack  int `json:"attack"`
+	Defense int `json:"defense"`
+	Speed   int `json:"speed"`
+}
+
+// StatsOf calcule les caractéristiques d'un combattant : le palier de niveau
+// fixe la base, chaque compétence (jusqu'à maxCountedSkills) renforce
+// l'attaque et la vitesse
+func StatsOf(f Fighter) Stats {
+	tier := LevelTier(f.Level)
+	skills := min(len(f.Skills), maxCountedSkills)
+
+	return Stats{
+		Tier:    tier,
+		HP:      100 + 40*tier,
+		Attack:  12 + 6*tier + 2*skills,
+		Defense: 4 + 3*tier,
+		Speed:   10 + 2*tier + skills,
+	}
+}
+
+// LevelTier retourne le palier d'un niveau (defaultTier s'il est inconnu)
+func LevelTier(level string) int {
+	if tier, ok := levelTiers[strings.ToLower(strings.TrimSpace(level))]; ok {
+		return tier
+	}
+	return defaultTier
+}
+
+// HighestLevel retourne le niveau de plus haut palier (vide si aucun)
+func HighestLevel(levels []string) string {
+	best := ""
+	for _, level := range levels {
+		if best == "" || LevelTier(level) > LevelTier(best) {
+			best = level
+		}
+	}
+	return best
+}
+
+// ============================================================
+// AVANTAGES
+// ============================================================
+
+// AttributeMultiplier retourne le multiplicateur de dégâts d'un attaquant
+// sur un défenseur selon leurs attributs
+func AttributeMultiplier(attacker, defender string) float64 {
+	attacker, defender = normalizeAttribute(attacker), normalizeAttribute(defender)
+	switch {
+	case beats[attacker] == defender && defender != "":
+		return AdvantageMultiplier
+	case beats[defender] == attacker && attacker != "":
+		return DisadvantageMultiplier
+	}
+	return NeutralMultiplier
+}
+
+// normalizeAttribute ramène un attribut à l'orthographe des constantes
+func normalizeAttribute(attribute string) string {
+	for _, known := range []string{Vaccine, Data, Virus, Free} {
+		if strings.EqualFold(strings.TrimSpace(attribute), known) {
+			return known
+		}
+	}
+	return ""
+}
+
+// Rating est la note de puissance d'un combattant face à un adversaire :
+// dégâts attendus par tour rapportés à la résistance de l'adversaire.
+// Plus elle est haute, plus le combattant est favori.
+func Rating(f, opponent Fighter) float64 {
+	stats, other := StatsOf(f), StatsOf(opponent)
+
+	damage := expectedDamage(stats, other, AttributeMultiplier(f.Attribute, opponent.Attribute))
+	return 100 * damage / float64(other.HP)
+}
+
+// expectedDamage calcule les dégâts moyens d'une attaque (hors aléa et critique)
+func expectedDamage(attacker, defender Stats, multiplier float64) float64 {
+	return max(1, float64(attacker.Attack)*multiplier-float64(defender.Defense))
+}
@@ -0,0 +1,117 @@
+package battle
+
+import "testing"
+
+func TestAttributeMultiplier(t *testing.T) {
+	tests := []struct {
+		attacker, defender string
+		want               float64
+	}{
+		// Triangle : Vaccine > Virus > Data > Vaccine
+		{attacker: Vaccine, defender: Virus, want: AdvantageMultiplier},
+		{attacker: Virus, defender: Data, want: AdvantageMultiplier},
+		{attacker: Data, defender: Vaccine, want: AdvantageMultiplier},
+		{attacker: Virus, defender: Vaccine, want: DisadvantageMultiplier},
+		{attacker: Data, defender: Virus, want: DisadvantageMultiplier},
+		{attacker: Vaccine, defender: Data, want: DisadvantageMultiplier},
+		{attacker: Vaccine, defender: Vaccine, want: NeutralMultiplier},
+
+		// Free, attribut vide ou inconnu : neutre dans les deux sens
+		{attacker: Free, defender: Virus, want: NeutralMultiplier},
+		{attacker: Data, defender: Free, want: NeutralMultiplier},
+		{attacker: "", defender: Vaccine, want: NeutralMultiplier},
+		{attacker: Virus, defender: "", want: NeutralMultiplier},
+		{attacker: "", defender: "", want: NeutralMultiplier},
+		{attacker: "Unknown", defender: Data, want: NeutralMultiplier},
+
+		// Casse et espaces ignorés
+		{attacker: " vaccine ", defender: "VIRUS", want: AdvantageMultiplier},
+	}
+
+	for _, tt := range tests {
+		if got := AttributeMultiplier(tt.attacker, tt.defender); got != tt.want {
+			t.Errorf("AttributeMultiplier(%q, %q) = %v, attendu %v", tt.attacker, tt.defender, got, tt.want)
+		}
+	}
+}
+
+func TestLevelTier(t *testing.T) {
+	tests := []struct {
+		level string
+		want  int
+	}{
+		{level: "Fresh", want: 1},
+		{level: "In-Training", want: 2},
+		{level: "Rookie", want: 3},
+		{level: "Champion", want: 4},
+		{level: "Armor", want: 4},
+		{level: "Ultimate", want: 5},
+		{level: "Mega", want: 6},
+		{level: "Ultra", want: 7},
+		{level: " mega ", want: 6},
+		{level: "", want: defaultTier},
+		{level: "Unknown", want: defaultTier},
+	}
+
+	for _, tt := range tests {
+		if got := LevelTier(tt.level); got != tt.want {
+			t.Errorf("LevelTier(%q) = %d, attendu %d", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestStatsOf(t *testing.T) {
+	manySkills := make([]string, maxCountedSkills+5)
+
+	tests := []struct {
+		name    string
+		fighter Fighter
+		want    Stats
+	}{
+		{
+			name:    "Rookie sans compétence",
+			fighter: Fighter{Level: "Rookie"},
+			want:    Stats{Tier: 3, HP: 220, Attack: 30, Defense: 13, Speed: 16},
+		},
+		{
+			name:    "Mega avec deux compétences",
+			fighter: Fighter{Level: "Mega", Skills: []string{"Gaia Force", "Brave Tornado"}},
+			want:    Stats{Tier: 6, HP: 340, Attack: 52, Defense: 22, Speed: 24},
+		},
+		{
+			name:    "compétences plafonnées",
+			fighter: Fighter{Level: "Fresh", Skills: manySkills},
+			want:    Stats{Tier: 1, HP: 140, Attack: 38, Defense: 7, Speed: 22},
+		},
+		{
+			name:    "niveau inconnu",
+			fighter: Fighter{},
+			want:    Stats{Tier: defaultTier, HP: 220, Attack: 30, Defense: 13, Speed: 16},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatsOf(tt.fighter); got != tt.want {
+				t.Errorf("StatsOf() = %+v, attendu %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighestLevel(t *testing.T) {
+	tests := []struct {
+		levels []string
+		want   string
+	}{
+		{levels: []string{"Rookie", "Mega", "Champion"}, want: "Mega"},
+		{levels: []string{"Armor", "Champion"}, want: "Armor"}, // Égalité : le premier
+		{levels: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := HighestLevel(tt.levels); got != tt.want {
+			t.Errorf("HighestLevel(%v) = %q, attendu %q", tt.levels, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package battle
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Paramètres de la simulation
+const (
+	MaxRounds      = 20   // Au-delà, le combat est jugé aux points de vie restants
+	criticalChance = 0.1  // Probabilité d'un coup critique
+	criticalBonus  = 1.5  // Multiplicateur d'un coup critique
+	damageSpread   = 0.15 // Variation aléatoire des dégâts (±15%)
+)
+
+// Issues d'un combat (Result.Outcome)
+const (
+	OutcomeKO     = "ko"     // Un combattant est tombé à 0 PV
+	OutcomePoints = "points" // Limite de tours atteinte, victoire aux PV restants
+	OutcomeDraw   = "draw"   // Limite de tours atteinte à égalité
+)
+
+// ============================================================
+// RÉSULTAT
+// ============================================================
+
+// Corner est un combattant avec ses caractéristiques et son état final
+type Corner struct {
+	Fighter    Fighter `json:"fighter"`
+	Stats      Stats   `json:"stats"`
+	Rating     float64 `json:"rating"`     // Note de puissance face à l'adversaire
+	Multiplier float64 `json:"multiplier"` // Multiplicateur d'attribut face à l'adversaire
+	HP         int     `json:"hp"`         // Points de vie restants
+}
+
+// Event est une attaque du journal de combat
+type Event struct {
+	Attacker      string `json:"attacker"`
+	Defender      string `json:"defender"`
+	Skill         string `json:"skill"`
+	Damage        int    `json:"damage"`
+	Critical      bool   `json:"critical"`
+	Effectiveness string `json:"effectiveness,omitempty"` // "super efficace", "peu efficace"
+	DefenderHP    int    `json:"defenderHp"`
+	KO            bool   `json:"ko"`
+}
+
+// Round regroupe les attaques d'un tour
+type Round struct {
+	Number int     `json:"number"`
+	Events []Event `json:"events"`
+}
+
+// Result est le déroulé complet d'un combat
+type Result struct {
+	A       Corner  `json:"a"`
+	B       Corner  `json:"b"`
+	Seed    uint64  `json:"seed"`
+	Rounds  []Round `json:"rounds"`
+	Outcome string  `json:"outcome"`
+	Winner  string  `json:"winner"` // "a", "b" ou vide en cas de match nul
+}
+
+// WinnerCorner retourne le vainqueur (nil en cas de match nul)
+func (r *Result) WinnerCorner() *Corner {
+	switch r.Winner {
+	case "a":
+		return &r.A
+	case "b":
+		return &r.B
+	}
+	return nil
+}
+
+// ============================================================
+// SIMULATION
+// ============================================================
+
+// Simulate fait combattre a et b tour par tour. Le plus rapide attaque en
+// premier à chaque tour ; les dégâts dépendent de l'attaque, de la défense,
+// du triangle des attributs, d'une part d'aléa et des coups critiques.
+// À graine identique, le combat se déroule toujours de la même façon.
+func Simulate(a, b Fighter, seed uint64) *Result {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+
+	result := &Result{
+		A:    newCorner(a, b),
+		B:    newCorner(b, a),
+		Seed: seed,
+	}
+
+	first, second := &result.A, &result.B
+	if second.Stats.Speed > first.Stats.Speed || (second.Stats.Speed == first.Stats.Speed && rng.IntN(2) == 1) {
+		first, second = second, first
+	}
+
+	for number := 1; number <= MaxRounds; number++ {
+		round := Round{Number: number}
+
+		for _, turn := range [][2]*Corner{{first, second}, {second, first}} {
+			event := attack(rng, turn[0], turn[1])
+			round.Events = append(round.Events, event)
+			if event.KO {
+				result.Rounds = append(result.Rounds, round)
+				result.Outcome = OutcomeKO
+				result.Winner = result.side(turn[0])
+				return result
+			}
+		}
+
+		result.Rounds = append(result.Rounds, round)
+	}
+
+	// Limite de tours : victoire à la part de PV restants
+	ratioA := float64(result.A.HP) / float64(result.A.Stats.HP)
+	ratioB := float64(result.B.HP) / float64(result.B.Stats.HP)
+	switch {
+	case ratioA > ratioB:
+		result.Outcome, result.Winner = OutcomePoints, "a"
+	case ratioB > ratioA:
+		result.Outcome, result.Winner = OutcomePoints, "b"
+	default:
+		result.Outcome = OutcomeDraw
+	}
+	return result
+}
+
+// side retourne le côté ("a" ou "b") d'un combattant du résultat
+func (r *Result) side(corner *Corner) string {
+	if corner == &r.A {
+		return "a"
+	}
+	return "b"
+}
+
+// newCorner prépare un combattant face à son adversaire
+func newCorner(f, opponent Fighter) Corner {
+	stats := StatsOf(f)
+	return Corner{
+		Fighter:    f,
+		Stats:      stats,
+		Rating:     math.Round(Rating(f, opponent)*10) / 10,
+		Multiplier: AttributeMultiplier(f.Attribute, opponent.Attribute),
+		HP:         stats.HP,
+	}
+}
+
+// attack joue une attaque et met à jour les PV du défenseur
+func attack(rng *rand.Rand, attacker, defender *Corner) Event {
+	event := Event{
+		Attacker: attacker.Fighter.Name,
+		Defender: defender.Fighter.Name,
+		Skill:    "Attaque",
+	}
+	if skills := attacker.Fighter.Skills; len(skills) > 0 {
+		event.Skill = skills[rng.IntN(len(skills))]
+	}
+
+	damage := expectedDamage(attacker.Stats, defender.Stats, attacker.Multiplier)
+	damage *= 1 + damageSpread*(2*rng.Float64()-1)
+	if rng.Float64() < criticalChance {
+		event.Critical = true
+		damage *= criticalBonus
+	}
+
+	switch attacker.Multiplier {
+	case AdvantageMultiplier:
+		event.Effectiveness = "super efficace"
+	case DisadvantageMultiplier:
+		event.Effectiveness = "peu efficace"
+	}
+
+	event.Damage = max(1, int(math.Round(damage)))
+	defender.HP = max(0, defender.HP-event.Damage)
+	event.DefenderHP = defender.HP
+	event.KO = defender.HP == 0
+	return event
+}
@@ -0,0 +1,76 @@
+package battle
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	agumon  = Fighter{ID: 1, Name: "Agumon", Attribute: Vaccine, Level: "Rookie", Skills: []string{"Pepper Breath", "Claw Attack"}}
+	gabumon = Fighter{ID: 2, Name: "Gabumon", Attribute: Data, Level: "Rookie", Skills: []string{"Blue Blaster"}}
+	devimon = Fighter{ID: 3, Name: "Devimon", Attribute: Virus, Level: "Champion", Skills: []string{"Death Claw"}}
+)
+
+func TestSimulateDeterministic(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Fighter
+		seed uint64
+	}{
+		{name: "désavantage d'attribut", a: agumon, b: gabumon, seed: 42},
+		{name: "avantage d'attribut", a: agumon, b: devimon, seed: 7},
+		{name: "même combattant", a: gabumon, b: gabumon, seed: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := Simulate(tt.a, tt.b, tt.seed)
+			second := Simulate(tt.a, tt.b, tt.seed)
+			if !reflect.DeepEqual(first, second) {
+				t.Fatalf("deux combats de graine %d diffèrent:\n%+v\n%+v", tt.seed, first, second)
+			}
+
+			if len(first.Rounds) == 0 || len(first.Rounds) > MaxRounds {
+				t.Errorf("%d tours joués, attendu entre 1 et %d", len(first.Rounds), MaxRounds)
+			}
+			switch first.Outcome {
+			case OutcomeKO, OutcomePoints:
+				if first.WinnerCorner() == nil {
+					t.Errorf("issue %s sans vainqueur", first.Outcome)
+				}
+			case OutcomeDraw:
+				if first.Winner != "" {
+					t.Errorf("match nul avec le vainqueur %q", first.Winner)
+				}
+			default:
+				t.Errorf("issue inconnue %q", first.Outcome)
+			}
+		})
+	}
+}
+
+func TestSimulateSeedChangesLog(t *testing.T) {
+	reference := Simulate(agumon, devimon, 1)
+	for seed := uint64(2); seed < 20; seed++ {
+		if !reflect.DeepEqual(Simulate(agumon, devimon, seed).Rounds, reference.Rounds) {
+			return
+		}
+	}
+	t.Error("le journal est identique pour toutes les graines, l'aléa n'est pas utilisé")
+}
+
+func TestSimulateEffectiveness(t *testing.T) {
+	result := Simulate(agumon, devimon, 3)
+
+	want := map[string]string{agumon.Name: "super efficace", devimon.Name: "peu efficace"}
+	for _, round := range result.Rounds {
+		for _, event := range round.Events {
+			if event.Effectiveness != want[event.Attacker] {
+				t.Fatalf("attaque de %s: efficacité %q, attendu %q", event.Attacker, event.Effectiveness, want[event.Attacker])
+			}
+			if event.Damage < 1 {
+				t.Errorf("attaque de %s: %d dégâts, attendu au moins 1", event.Attacker, event.Damage)
+			}
+		}
+	}
+}
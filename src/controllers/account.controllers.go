@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+)
+
+// AccountController regroupe les handlers des comptes utilisateurs :
+// inscription, connexion, déconnexion et page du compte
+type AccountController struct {
+	users    *services.UserStore
+	sessions *services.SessionStore
+}
+
+// NewAccountController crée un contrôleur utilisant les comptes et sessions fournis
+func NewAccountController(users *services.UserStore, sessions *services.SessionStore) *AccountController {
+	return &AccountController{users: users, sessions: sessions}
+}
+
+// ============================================================
+// PAGES DU COMPTE
+// ============================================================
+
+// DisplayRegister affiche le formulaire d'inscription (GET) ou crée le
+// compte puis connecte l'utilisateur (POST)
+func (c *AccountController) DisplayRegister(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		helper.RenderPage(w, r, "account_register", map[string]interface{}{}, nil, nil)
+		return
+	}
+
+	username := r.FormValue("username")
+	password := r.FormValue("password")
+	if password != r.FormValue("confirm") {
+		renderAccountForm(w, r, "account_register", username, "Les mots de passe ne correspondent pas")
+		return
+	}
+
+	user, err := c.users.Register(username, password)
+	if errors.Is(err, services.ErrInvalidInput) || errors.Is(err, services.ErrConflict) {
+		renderAccountForm(w, r, "account_register", username, err.Error())
+		return
+	}
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	c.login(w, r, user)
+	http.Redirect(w, r, "/account", http.StatusSeeOther)
+}
+
+// DisplayLogin affiche le formulaire de connexion (GET) ou vérifie les
+// identifiants puis revient à la page indiquée par le champ redirect (POST)
+func (c *AccountController) DisplayLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		templateData := map[string]interface{}{
+			"Redirect": localRedirect(r, "/account"),
+		}
+		helper.RenderPage(w, r, "account_login", templateData, nil, nil)
+		return
+	}
+
+	username := r.FormValue("username")
+	user, err := c.users.Authenticate(username, r.FormValue("password"))
+	if err != nil {
+		renderAccountForm(w, r, "account_login", username, "Nom d'utilisateur ou mot de passe incorrect")
+		return
+	}
+
+	c.login(w, r, user)
+	http.Redirect(w, r, localRedirect(r, "/account"), http.StatusSeeOther)
+}
+
+// Logout déconnecte l'utilisateur (POST) en détruisant sa session, puis
+// revient à l'accueil
+func (c *AccountController) Logout(w http.ResponseWriter, r *http.Request) {
+	if !requirePost(w, r) {
+		return
+	}
+
+	session, _ := services.SessionFrom(r.Context())
+	c.sessions.Destroy(session)
+	helper.ClearSessionCookie(w, r)
+	http.Redirect(w, r, "/digimons", http.StatusSeeOther)
+}
+
+// DisplayAccount affiche le compte de l'utilisateur connecté, ou renvoie
+// vers la connexion
+func (c *AccountController) DisplayAccount(w http.ResponseWriter, r *http.Request) {
+	user, ok := services.CurrentUser(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/account/login?redirect=/account", http.StatusSeeOther)
+		return
+	}
+
+	helper.RenderTemplate(w, r, "account", map[string]interface{}{"User": user})
+}
+
+// ============================================================
+// API DU COMPTE
+// ============================================================
+
+// APIMe renvoie l'utilisateur connecté (401 pour un visiteur anonyme)
+func (c *AccountController) APIMe(w http.ResponseWriter, r *http.Request) {
+	user, ok := services.CurrentUser(r.Context())
+	if !ok {
+		helper.RenderJSONError(w, r, http.StatusUnauthorized, "unauthorized", "Aucun utilisateur connecté")
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, user, nil)
+}
+
+// ============================================================
+// OUTILS
+// ============================================================
+
+// login ouvre une nouvelle session connectée pour l'utilisateur
+func (c *AccountController) login(w http.ResponseWriter, r *http.Request, user *services.User) {
+	session, _ := services.SessionFrom(r.Context())
+	helper.SetSessionCookie(w, r, c.sessions.Login(session, user.ID), c.sessions)
+}
+
+// renderAccountForm réaffiche un formulaire du compte avec un message d'erreur.
+// Le mot de passe n'est jamais renvoyé.
+func renderAccountForm(w http.ResponseWriter, r *http.Request, name, username, message string) {
+	templateData := map[string]interface{}{
+		"Username": username,
+		"Redirect": localRedirect(r, "/account"),
+		"Error":    message,
+	}
+
+	helper.RenderPage(w, r, name, templateData, nil, nil)
+}
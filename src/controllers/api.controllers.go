@@ -0,0 +1,457 @@
+package controllers
+
+import (
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// API JSON - LISTE ET PAGINATION
+// ============================================================
+
+// APIListDigimons renvoie la liste des Digimons (équivalent JSON de /digimons)
+func (c *DigimonController) APIListDigimons(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	order, err := parseSortOrder(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	req := parsePageRequest(r, 100)
+	opts := &services.DigimonListOptions{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Sort:     order,
+	}
+
+	source, err := c.listSource(order)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	data, _, err := source.GetAllDigimons(ctx, opts)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, data.Content, newPagination(r, req, data.TotalElements).meta())
+}
+
+// APIListDigimonsWithPagination renvoie une page de la liste (?page=)
+func (c *DigimonController) APIListDigimonsWithPagination(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	order, err := parseSortOrder(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	req := parsePageRequest(r, 20)
+	opts := &services.DigimonListOptions{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Sort:     order,
+	}
+
+	source, err := c.listSource(order)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	data, _, err := source.GetAllDigimons(ctx, opts)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, data.Content, newPagination(r, req, data.TotalElements).meta())
+}
+
+// ============================================================
+// API JSON - RECHERCHE
+// ============================================================
+
+// APISearch recherche les Digimons par nom (?query=)
+func (c *DigimonController) APISearch(w http.ResponseWriter, r *http.Request) {
+	c.apiSearch(w, r, false)
+}
+
+// APISearchAdvanced recherche les Digimons par nom avec option exacte (?query=&exact=)
+func (c *DigimonController) APISearchAdvanced(w http.ResponseWriter, r *http.Request) {
+	c.apiSearch(w, r, isChecked(r.FormValue("exact")))
+}
+
+func (c *DigimonController) apiSearch(w http.ResponseWriter, r *http.Request, exact bool) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	query := strings.TrimSpace(r.FormValue("query"))
+	if query == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Paramètre query manquant")
+		return
+	}
+
+	order, err := parseSortOrder(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	req := parsePageRequest(r, 50)
+	result, err := c.searchDigimons(ctx, query, exact, order, req)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, result.Digimons, newPagination(r, req, result.Total).meta())
+}
+
+// ============================================================
+// API JSON - AUTOCOMPLÉTION
+// ============================================================
+
+// Nombre de suggestions renvoyées par /api/suggest (?limit=)
+const (
+	defaultSuggestLimit = 8
+	maxSuggestLimit     = 20
+)
+
+// APISuggest propose les Digimons dont le nom commence par le texte saisi
+// (?q=), pour l'autocomplétion du champ de recherche. La réponse vient de
+// l'index du catalogue local, reconstruit à chaque synchronisation.
+func (c *DigimonController) APISuggest(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := c.catalog.Snapshot()
+	if !ok {
+		renderAPIServiceError(w, r, services.ErrCatalogUnavailable)
+		return
+	}
+
+	limit := defaultSuggestLimit
+	if value, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && value > 0 {
+		limit = min(value, maxSuggestLimit)
+	}
+
+	suggestions := snapshot.SearchIndex().Complete(r.URL.Query().Get("q"), limit)
+	helper.RenderJSON(w, r, http.StatusOK, suggestions, nil)
+}
+
+// ============================================================
+// API JSON - FILTRAGE
+// ============================================================
+
+// APIFilter filtre les Digimons par niveau, attribut et X-Antibody
+func (c *DigimonController) APIFilter(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	order, err := parseSortOrder(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	req := parsePageRequest(r, 100)
+	opts := parseFilterOptions(r, req)
+	opts.Sort = order
+
+	source, err := c.listSource(order)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	data, _, err := source.GetAllDigimons(ctx, opts)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, data.Content, newPagination(r, req, data.TotalElements).meta())
+}
+
+// APIFilterAdvanced filtre localement le catalogue et renvoie les facettes
+func (c *DigimonController) APIFilterAdvanced(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "bad_request", "Erreur parsing formulaire")
+		return
+	}
+
+	snapshot, ok := c.catalog.Snapshot()
+	if !ok {
+		renderAPIServiceError(w, r, services.ErrCatalogUnavailable)
+		return
+	}
+
+	query := parseDigimonQuery(r)
+	order, err := parseSortOrder(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+	query.Sort = order
+
+	result := services.RunQuery(snapshot.Digimons, query)
+
+	// Les facettes et le total portent sur tous les résultats, seule la
+	// liste des Digimons est découpée
+	req := parsePageRequest(r, 50)
+	page := *result
+	page.Digimons = services.Paginate(result.Digimons, req.Page, req.PageSize)
+
+	helper.RenderJSON(w, r, http.StatusOK, page, newPagination(r, req, result.Total).meta())
+}
+
+// ============================================================
+// API JSON - DÉTAILS
+// ============================================================
+
+// APIDigimonDetails renvoie un Digimon complet par son ID (?id=)
+func (c *DigimonController) APIDigimonDetails(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "ID manquant")
+		return
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", "ID invalide")
+		return
+	}
+
+	digimon, _, err := c.source.GetDigimonByID(ctx, id)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, digimon, nil)
+}
+
+// APIDigimonDetailsByName renvoie un Digimon complet par son nom (?name=)
+func (c *DigimonController) APIDigimonDetailsByName(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Nom manquant")
+		return
+	}
+
+	digimon, _, err := c.source.GetDigimonByName(ctx, name)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, digimon, nil)
+}
+
+// ============================================================
+// API JSON - PAR RESSOURCES
+// ============================================================
+
+// APIDigimonsByAttribute renvoie les Digimons d'un attribut (?attribute=)
+func (c *DigimonController) APIDigimonsByAttribute(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	attributeName := r.URL.Query().Get("attribute")
+	if attributeName == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Attribut manquant")
+		return
+	}
+
+	attribute, _, err := c.source.GetAttributeByName(ctx, attributeName)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages sur une copie,
+	// la ressource pouvant provenir du cache
+	req := parsePageRequest(r, 50)
+	page := *attribute
+	page.Digimons = services.Paginate(attribute.Digimons, req.Page, req.PageSize)
+
+	helper.RenderJSON(w, r, http.StatusOK, page, newPagination(r, req, len(attribute.Digimons)).meta())
+}
+
+// APIDigimonsByLevel renvoie les Digimons d'un niveau (?level=)
+func (c *DigimonController) APIDigimonsByLevel(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	levelName := r.URL.Query().Get("level")
+	if levelName == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Niveau manquant")
+		return
+	}
+
+	level, _, err := c.source.GetLevelByName(ctx, levelName)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages sur une copie,
+	// la ressource pouvant provenir du cache
+	req := parsePageRequest(r, 50)
+	page := *level
+	page.Digimons = services.Paginate(level.Digimons, req.Page, req.PageSize)
+
+	helper.RenderJSON(w, r, http.StatusOK, page, newPagination(r, req, len(level.Digimons)).meta())
+}
+
+// APIDigimonsByType renvoie les Digimons d'un type (?type=)
+func (c *DigimonController) APIDigimonsByType(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	typeName := r.URL.Query().Get("type")
+	if typeName == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Type manquant")
+		return
+	}
+
+	digimonType, _, err := c.source.GetTypeByName(ctx, typeName)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages sur une copie,
+	// la ressource pouvant provenir du cache
+	req := parsePageRequest(r, 50)
+	page := *digimonType
+	page.Digimons = services.Paginate(digimonType.Digimons, req.Page, req.PageSize)
+
+	helper.RenderJSON(w, r, http.StatusOK, page, newPagination(r, req, len(digimonType.Digimons)).meta())
+}
+
+// APIDigimonsByField renvoie les Digimons d'un champ (?field=)
+func (c *DigimonController) APIDigimonsByField(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	fieldName := r.URL.Query().Get("field")
+	if fieldName == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Champ manquant")
+		return
+	}
+
+	field, _, err := c.source.GetFieldByName(ctx, fieldName)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages sur une copie,
+	// la ressource pouvant provenir du cache
+	req := parsePageRequest(r, 50)
+	page := *field
+	page.Digimons = services.Paginate(field.Digimons, req.Page, req.PageSize)
+
+	helper.RenderJSON(w, r, http.StatusOK, page, newPagination(r, req, len(field.Digimons)).meta())
+}
+
+// APIDigimonsBySkill renvoie les Digimons partageant une compétence (?skill=)
+func (c *DigimonController) APIDigimonsBySkill(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	skillName := r.URL.Query().Get("skill")
+	if skillName == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Compétence manquante")
+		return
+	}
+
+	skill, _, err := c.source.GetSkillByName(ctx, skillName)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages sur une copie,
+	// la ressource pouvant provenir du cache
+	req := parsePageRequest(r, 50)
+	page := *skill
+	page.Digimons = services.Paginate(skill.Digimons, req.Page, req.PageSize)
+
+	helper.RenderJSON(w, r, http.StatusOK, page, newPagination(r, req, len(skill.Digimons)).meta())
+}
+
+// ============================================================
+// API JSON - OPTIONS DES FILTRES
+// ============================================================
+
+// APIFilterOptions renvoie les listes de niveaux, attributs, types et champs.
+// - ?kind= limite la réponse à une seule liste (level, attribute, type, field)
+// - ?q= ne garde que les valeurs contenant le texte (autocomplétion)
+func (c *DigimonController) APIFilterOptions(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	options := c.options.Get(ctx)
+	kind := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("kind")))
+	search := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+
+	if kind == "" {
+		helper.RenderJSON(w, r, http.StatusOK, services.FilterOptions{
+			Levels:     matchOptions(options.Levels, search),
+			Attributes: matchOptions(options.Attributes, search),
+			Types:      matchOptions(options.Types, search),
+			Fields:     matchOptions(options.Fields, search),
+			UpdatedAt:  options.UpdatedAt,
+			Fallback:   options.Fallback,
+		}, nil)
+		return
+	}
+
+	switch kind {
+	case services.ResourceLevel, services.ResourceAttribute, services.ResourceType, services.ResourceField:
+	default:
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter",
+			"kind doit valoir level, attribute, type ou field")
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, matchOptions(options.Values(kind), search), nil)
+}
+
+// matchOptions filtre les valeurs contenant le texte recherché (sans casse)
+func matchOptions(values []string, search string) []string {
+	matches := []string{}
+	for _, value := range values {
+		if strings.Contains(strings.ToLower(value), search) {
+			matches = append(matches, value)
+		}
+	}
+	return matches
+}
+
+// ============================================================
+// API JSON - STATISTIQUES
+// ============================================================
+
+// APIStats renvoie les compteurs de la source : cache, limiteur de débit
+// (appels retardés ou abandonnés) et état du disjoncteur
+func (c *DigimonController) APIStats(w http.ResponseWriter, r *http.Request) {
+	helper.RenderJSON(w, r, http.StatusOK, services.SourceStats(c.source), nil)
+}
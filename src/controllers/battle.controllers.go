@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"guide/battle"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// COMBAT
+// ============================================================
+
+// DisplayBattle simule un combat entre deux Digimons (?a=&b=, ID ou nom).
+//   - ?seed= rejoue un combat précis ; par défaut la graine dépend des deux
+//     Digimons, le même duel donne donc toujours le même déroulé
+//   - Sans paramètres, seul le formulaire est affiché
+func (c *DigimonController) DisplayBattle(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	aQuery := strings.TrimSpace(r.URL.Query().Get("a"))
+	bQuery := strings.TrimSpace(r.URL.Query().Get("b"))
+	templateData := map[string]interface{}{
+		"AQuery": aQuery,
+		"BQuery": bQuery,
+	}
+	if aQuery == "" && bQuery == "" {
+		helper.RenderPage(w, r, "battle", templateData, nil, nil)
+		return
+	}
+
+	seed, hasSeed, err := parseSeed(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.battle(ctx, r, seed, hasSeed)
+	if err != nil {
+		var missing missingFighterError
+		if errors.As(err, &missing) {
+			http.Error(w, missing.Error(), http.StatusBadRequest)
+			return
+		}
+		renderServiceError(w, r, err)
+		return
+	}
+
+	templateData["Result"] = result
+	helper.RenderPage(w, r, "battle", templateData, result, nil)
+}
+
+// APIBattle renvoie le déroulé complet d'un combat (?a=&b=&seed=)
+func (c *DigimonController) APIBattle(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	seed, hasSeed, err := parseSeed(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	result, err := c.battle(ctx, r, seed, hasSeed)
+	if err != nil {
+		var missing missingFighterError
+		if errors.As(err, &missing) {
+			helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", missing.Error())
+			return
+		}
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, result, nil)
+}
+
+// missingFighterError signale un combattant non renseigné (?a= ou ?b=)
+type missingFighterError string
+
+func (e missingFighterError) Error() string {
+	return fmt.Sprintf("Digimon %s manquant (ID ou nom)", string(e))
+}
+
+// battle récupère les deux combattants puis simule le combat.
+// Sans graine imposée (hasSeed à false), la graine est dérivée des deux IDs.
+func (c *DigimonController) battle(ctx context.Context, r *http.Request, seed uint64, hasSeed bool) (*battle.Result, error) {
+	fighters := make([]battle.Fighter, 0, 2)
+	for _, param := range []string{"a", "b"} {
+		query := strings.TrimSpace(r.URL.Query().Get(param))
+		if query == "" {
+			return nil, missingFighterError(param)
+		}
+
+		digimon, err := resolveDigimon(ctx, c.source, query)
+		if err != nil {
+			return nil, err
+		}
+		fighters = append(fighters, fighterFrom(digimon))
+	}
+
+	if !hasSeed {
+		seed = uint64(fighters[0].ID)<<32 | uint64(fighters[1].ID)
+	}
+	return battle.Simulate(fighters[0], fighters[1], seed), nil
+}
+
+// fighterFrom convertit un Digimon en combattant : premier attribut,
+// niveau le plus élevé et noms des compétences
+func fighterFrom(digimon *services.Digimon) battle.Fighter {
+	fighter := battle.Fighter{
+		ID:     digimon.ID,
+		Name:   digimon.Name,
+		Image:  digimon.MainImage(),
+		Skills: []string{},
+	}
+	if len(digimon.Attributes) > 0 {
+		fighter.Attribute = digimon.Attributes[0].Attribute
+	}
+
+	levels := make([]string, 0, len(digimon.Levels))
+	for _, level := range digimon.Levels {
+		levels = append(levels, level.Level)
+	}
+	fighter.Level = battle.HighestLevel(levels)
+
+	for _, skill := range digimon.Skills {
+		fighter.Skills = append(fighter.Skills, skill.Skill)
+	}
+	return fighter
+}
+
+// parseSeed lit la graine de simulation (?seed=). ok vaut false si la
+// graine est absente, pour distinguer ce cas d'une graine explicite à 0.
+func parseSeed(r *http.Request) (seed uint64, ok bool, err error) {
+	raw := strings.TrimSpace(r.URL.Query().Get("seed"))
+	if raw == "" {
+		return 0, false, nil
+	}
+	seed, err = strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("graine invalide : %q", raw)
+	}
+	return seed, true, nil
+}
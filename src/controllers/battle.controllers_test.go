@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    uint64
+		wantOK  bool
+		wantErr bool
+	}{
+		{name: "graine absente", query: "", want: 0, wantOK: false},
+		{name: "graine vide", query: "?seed=", want: 0, wantOK: false},
+		{name: "graine explicite à 0", query: "?seed=0", want: 0, wantOK: true},
+		{name: "graine explicite", query: "?seed=42", want: 42, wantOK: true},
+		{name: "graine invalide", query: "?seed=abc", wantErr: true},
+		{name: "graine négative", query: "?seed=-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/battle"+tt.query, nil)
+			seed, ok, err := parseSeed(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erreur = %v, attendu une erreur: %t", err, tt.wantErr)
+			}
+			if seed != tt.want || ok != tt.wantOK {
+				t.Errorf("parseSeed = (%d, %t), attendu (%d, %t)", seed, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
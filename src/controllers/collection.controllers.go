@@ -0,0 +1,433 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Bornes des collections
+const (
+	collectionPageSize = 24      // Digimons affichés par page d'une collection
+	maxImportSize      = 1 << 20 // Taille maximum d'un fichier importé (1 Mo)
+)
+
+// ============================================================
+// PAGES DES COLLECTIONS
+// ============================================================
+
+// DisplayCollections affiche les collections de l'utilisateur connecté avec
+// les formulaires de création et d'import
+func (c *DigimonController) DisplayCollections(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	templateData := map[string]interface{}{
+		"Collections": c.collections.List(user.ID),
+	}
+
+	helper.RenderTemplate(w, r, "collections", templateData)
+}
+
+// DisplayCollection affiche les Digimons d'une collection (?slug=), page par page
+func (c *DigimonController) DisplayCollection(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	collection, err := c.collections.Get(user.ID, r.URL.Query().Get("slug"))
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	req := parsePageRequest(r, collectionPageSize)
+	export := map[string]interface{}{
+		"Collection": collection,
+		"Digimons":   c.collectionCards(ctx, services.Paginate(collection.IDs, req.Page, req.PageSize)),
+	}
+	pagination := newPagination(r, req, len(collection.IDs))
+	templateData := withView(export, map[string]interface{}{
+		"Pagination": pagination,
+		"Redirect":   r.URL.RequestURI(),
+	})
+
+	helper.RenderPage(w, r, "collection", templateData, export, pagination.meta())
+}
+
+// CreateCollection crée une collection (POST, champ name) puis l'affiche
+func (c *DigimonController) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	if !requirePost(w, r) {
+		return
+	}
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	collection, err := c.collections.Create(user.ID, r.FormValue("name"))
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, collectionURL(collection.Slug), http.StatusSeeOther)
+}
+
+// DeleteCollection supprime une collection (POST, champ slug)
+func (c *DigimonController) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	if !requirePost(w, r) {
+		return
+	}
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.collections.Delete(user.ID, r.FormValue("slug")); err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/collections", http.StatusSeeOther)
+}
+
+// AddToCollection ajoute un Digimon à une collection (POST, champs slug et
+// id) puis revient à la page indiquée par le champ redirect
+func (c *DigimonController) AddToCollection(w http.ResponseWriter, r *http.Request) {
+	c.changeCollection(w, r, c.collections.Add)
+}
+
+// RemoveFromCollection retire un Digimon d'une collection (POST, champs slug
+// et id) puis revient à la page indiquée par le champ redirect
+func (c *DigimonController) RemoveFromCollection(w http.ResponseWriter, r *http.Request) {
+	c.changeCollection(w, r, c.collections.Remove)
+}
+
+// ExportCollection télécharge une collection au format d'échange (?slug=).
+// Le fichier peut être réimporté tel quel.
+func (c *DigimonController) ExportCollection(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	slug := r.URL.Query().Get("slug")
+	export, err := c.collections.Export(user.ID, slug)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "collection-"+slug+".json"))
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(export)
+}
+
+// ImportCollection importe un fichier exporté (POST multipart, champ file)
+// dans la collection de même nom, puis l'affiche
+func (c *DigimonController) ImportCollection(w http.ResponseWriter, r *http.Request) {
+	if !requirePost(w, r) {
+		return
+	}
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	// Le corps du formulaire a déjà été lu par le middleware CSRF : la
+	// limite porte donc sur la taille du fichier reçu
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Fichier d'import manquant", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	if header.Size > maxImportSize {
+		http.Error(w, "Fichier d'import trop volumineux (1 Mo maximum)", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var export services.CollectionExport
+	if err := json.NewDecoder(file).Decode(&export); err != nil {
+		http.Error(w, "Fichier d'import illisible", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := c.collections.Import(user.ID, export)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, collectionURL(collection.Slug), http.StatusSeeOther)
+}
+
+// changeCollection applique un ajout ou un retrait demandé par un formulaire
+func (c *DigimonController) changeCollection(w http.ResponseWriter, r *http.Request,
+	change func(owner int, slug string, id int) (*services.Collection, error)) {
+	if !requirePost(w, r) {
+		return
+	}
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	slug, id, err := parseCollectionMember(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := change(user.ID, slug, id); err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, localRedirect(r, collectionURL(slug)), http.StatusSeeOther)
+}
+
+// ============================================================
+// API DES COLLECTIONS
+// ============================================================
+
+// APICollections renvoie les collections de l'utilisateur connecté
+func (c *DigimonController) APICollections(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAPIUser(w, r)
+	if !ok {
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, c.collections.List(user.ID), nil)
+}
+
+// APICollection renvoie une collection (?slug=) avec les cartes de la page
+// demandée de ses Digimons
+func (c *DigimonController) APICollection(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAPIUser(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	collection, err := c.collections.Get(user.ID, r.URL.Query().Get("slug"))
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	req := parsePageRequest(r, collectionPageSize)
+	data := map[string]interface{}{
+		"collection": collection,
+		"digimons":   c.collectionCards(ctx, services.Paginate(collection.IDs, req.Page, req.PageSize)),
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, data, newPagination(r, req, len(collection.IDs)).meta())
+}
+
+// APICreateCollection crée une collection (POST, champ name)
+func (c *DigimonController) APICreateCollection(w http.ResponseWriter, r *http.Request) {
+	if !requireAPIPost(w, r) {
+		return
+	}
+	user, ok := requireAPIUser(w, r)
+	if !ok {
+		return
+	}
+
+	collection, err := c.collections.Create(user.ID, r.FormValue("name"))
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusCreated, collection, nil)
+}
+
+// APIDeleteCollection supprime une collection (POST, champ slug)
+func (c *DigimonController) APIDeleteCollection(w http.ResponseWriter, r *http.Request) {
+	if !requireAPIPost(w, r) {
+		return
+	}
+	user, ok := requireAPIUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.collections.Delete(user.ID, r.FormValue("slug")); err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIAddToCollection ajoute un Digimon à une collection (POST, champs slug et id)
+func (c *DigimonController) APIAddToCollection(w http.ResponseWriter, r *http.Request) {
+	c.apiChangeCollection(w, r, c.collections.Add)
+}
+
+// APIRemoveFromCollection retire un Digimon d'une collection (POST, champs slug et id)
+func (c *DigimonController) APIRemoveFromCollection(w http.ResponseWriter, r *http.Request) {
+	c.apiChangeCollection(w, r, c.collections.Remove)
+}
+
+// APIImportCollection importe une collection envoyée en JSON dans le corps
+// de la requête (format de /collections/export)
+func (c *DigimonController) APIImportCollection(w http.ResponseWriter, r *http.Request) {
+	if !requireAPIPost(w, r) {
+		return
+	}
+	user, ok := requireAPIUser(w, r)
+	if !ok {
+		return
+	}
+
+	var export services.CollectionExport
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxImportSize)).Decode(&export); err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_body", "Corps JSON illisible ou trop volumineux")
+		return
+	}
+
+	collection, err := c.collections.Import(user.ID, export)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, collection, nil)
+}
+
+// apiChangeCollection applique un ajout ou un retrait et renvoie la collection
+func (c *DigimonController) apiChangeCollection(w http.ResponseWriter, r *http.Request,
+	change func(owner int, slug string, id int) (*services.Collection, error)) {
+	if !requireAPIPost(w, r) {
+		return
+	}
+	user, ok := requireAPIUser(w, r)
+	if !ok {
+		return
+	}
+
+	slug, id, err := parseCollectionMember(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	collection, err := change(user.ID, slug, id)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, collection, nil)
+}
+
+// ============================================================
+// OUTILS
+// ============================================================
+
+// collectionCards construit les cartes des Digimons d'une page de collection.
+// Comme pour les listes, la récupération des badges est bornée dans le temps.
+func (c *DigimonController) collectionCards(ctx context.Context, ids []int) []services.DigimonCard {
+	ctx, cancel := context.WithTimeout(ctx, hydrationTimeout)
+	defer cancel()
+
+	return services.CollectionCards(ctx, c.localSource(), ids)
+}
+
+// parseCollectionMember lit la collection (slug) et le Digimon (id) visés
+func parseCollectionMember(r *http.Request) (string, int, error) {
+	slug := strings.TrimSpace(r.FormValue("slug"))
+	if slug == "" {
+		return "", 0, fmt.Errorf("collection manquante")
+	}
+
+	id, err := strconv.Atoi(r.FormValue("id"))
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("ID invalide")
+	}
+	return slug, id, nil
+}
+
+// collectionURL retourne l'adresse de la page d'une collection
+func collectionURL(slug string) string {
+	return "/collections/view?slug=" + url.QueryEscape(slug)
+}
+
+// localRedirect retourne la page de retour demandée (champ redirect) si elle
+// est locale au site, fallback sinon. Les caractères de contrôle sont refusés :
+// les navigateurs ignorent tabulations et retours à la ligne, "/\t/site"
+// deviendrait "//site".
+func localRedirect(r *http.Request, fallback string) string {
+	target := r.FormValue("redirect")
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") ||
+		strings.ContainsFunc(target, unicode.IsControl) {
+		return fallback
+	}
+	return target
+}
+
+// requirePost refuse les requêtes autres que POST sur une action de page
+func requirePost(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodPost {
+		return true
+	}
+	w.Header().Set("Allow", http.MethodPost)
+	http.Error(w, "Méthode non autorisée", http.StatusMethodNotAllowed)
+	return false
+}
+
+// requireUser retourne l'utilisateur connecté. Un visiteur anonyme est
+// renvoyé vers la connexion, puis vers la page demandée (ou, pour un
+// formulaire, vers sa page de retour).
+func requireUser(w http.ResponseWriter, r *http.Request) (*services.User, bool) {
+	if user, ok := services.CurrentUser(r.Context()); ok {
+		return user, true
+	}
+
+	target := r.URL.RequestURI()
+	if r.Method != http.MethodGet {
+		target = localRedirect(r, "/collections")
+	}
+	http.Redirect(w, r, "/account/login?redirect="+url.QueryEscape(target), http.StatusSeeOther)
+	return nil, false
+}
+
+// requireAPIUser retourne l'utilisateur connecté (401 pour un visiteur anonyme)
+func requireAPIUser(w http.ResponseWriter, r *http.Request) (*services.User, bool) {
+	if user, ok := services.CurrentUser(r.Context()); ok {
+		return user, true
+	}
+	helper.RenderJSONError(w, r, http.StatusUnauthorized, "unauthorized", "Connexion requise")
+	return nil, false
+}
+
+// requireAPIPost refuse les requêtes autres que POST sur une action de l'API
+func requireAPIPost(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodPost {
+		return true
+	}
+	w.Header().Set("Allow", http.MethodPost)
+	helper.RenderJSONError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", "Méthode non autorisée, utilisez POST")
+	return false
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guide/services"
+)
+
+// importRequest construit un POST multipart dont le champ file contient content,
+// envoyé par un utilisateur connecté
+func importRequest(t *testing.T, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "collection.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(content))
+	form.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/collections/import", &body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	user := &services.User{ID: 1, Username: "tai"}
+	return r.WithContext(services.WithUser(r.Context(), user))
+}
+
+func TestImportCollectionSize(t *testing.T) {
+	export := `{"version":1,"name":"Dragons","ids":[1,2,3]}`
+
+	tests := []struct {
+		name       string
+		content    string
+		wantStatus int
+	}{
+		{name: "fichier valide", content: export, wantStatus: http.StatusSeeOther},
+		{name: "fichier à la limite", content: export + strings.Repeat(" ", maxImportSize-len(export)), wantStatus: http.StatusSeeOther},
+		// Export valide complété d'espaces : seule la taille le fait refuser
+		{name: "fichier trop volumineux", content: export + strings.Repeat(" ", maxImportSize), wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collections := services.NewCollectionStore(filepath.Join(t.TempDir(), "collections.json"))
+			controller := NewDigimonController(nil, nil, collections)
+
+			w := httptest.NewRecorder()
+			controller.ImportCollection(w, importRequest(t, tt.content))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("statut = %d, attendu %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			_, err := collections.Get(1, "dragons")
+			imported := err == nil
+			if imported != (tt.wantStatus == http.StatusSeeOther) {
+				t.Errorf("collection importée = %t, attendu %t", imported, !imported)
+			}
+		})
+	}
+}
+
+func TestLocalRedirect(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{name: "page locale", target: "/collections/dragons", want: "/collections/dragons"},
+		{name: "page locale avec paramètres", target: "/digimons?page=2&order=name", want: "/digimons?page=2&order=name"},
+		{name: "champ absent", target: "", want: "/collections"},
+		{name: "URL absolue", target: "https://evil.example/", want: "/collections"},
+		{name: "URL sans schéma", target: "//evil.example/", want: "/collections"},
+		{name: "barre oblique inverse", target: "/\\evil.example/", want: "/collections"},
+		{name: "chemin relatif", target: "evil.example", want: "/collections"},
+		{name: "schéma javascript", target: "javascript:alert(1)", want: "/collections"},
+		// Les navigateurs ignorent tabulations et retours à la ligne : "//evil.example"
+		{name: "tabulation", target: "/\t/evil.example/", want: "/collections"},
+		{name: "retour à la ligne", target: "/\n/evil.example/", want: "/collections"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"redirect": {tt.target}}
+			r := httptest.NewRequest(http.MethodPost, "/collections/add", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			if got := localRedirect(r, "/collections"); got != tt.want {
+				t.Errorf("localRedirect(%q) = %q, attendu %q", tt.target, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"fmt"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// COMPARAISON
+// ============================================================
+
+// DisplayCompare affiche côte à côte plusieurs Digimons (?ids=1,2,3).
+// - Les Digimons sont récupérés en parallèle
+// - Les valeurs communes à tous sont mises en évidence
+func (c *DigimonController) DisplayCompare(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	ids, err := parseCompareIDs(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	comparison, err := services.CompareDigimons(ctx, c.source, ids)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderTemplate(w, r, "digimon_compare", comparison)
+}
+
+// APICompare renvoie la matrice de comparaison de plusieurs Digimons (?ids=1,2,3)
+func (c *DigimonController) APICompare(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	ids, err := parseCompareIDs(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	comparison, err := services.CompareDigimons(ctx, c.source, ids)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, comparison, nil)
+}
+
+// parseCompareIDs lit la liste d'IDs séparés par des virgules (?ids=1,2,3).
+// Les doublons sont ignorés ; entre MinCompared et MaxCompared IDs sont acceptés.
+func parseCompareIDs(r *http.Request) ([]int, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get("ids"))
+	if raw == "" {
+		return nil, fmt.Errorf("paramètre ids manquant (ex: ids=1,2,3)")
+	}
+
+	ids := []int{}
+	for _, part := range strings.Split(raw, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("ID invalide : %q", part)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) < services.MinCompared || len(ids) > services.MaxCompared {
+		return nil, fmt.Errorf("indiquez entre %d et %d Digimons différents", services.MinCompared, services.MaxCompared)
+	}
+	return ids, nil
+}
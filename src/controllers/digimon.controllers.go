@@ -6,17 +6,55 @@ import (
 	"guide/helper"
 	"guide/services"
 	"log"
+	"maps"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// createContext crée un contexte avec timeout pour les requêtes API
-func createContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), 10*time.Second)
+// DigimonController regroupe les handlers Digimon et leurs dépendances.
+// La source de données est injectée à la construction, ce qui permet de
+// pointer l'application vers digi-api.com, un serveur local ou un faux backend.
+type DigimonController struct {
+	source      services.DigimonSource
+	catalog     *services.CatalogStore
+	collections *services.CollectionStore
+	options     *services.OptionsProvider
 }
 
+// NewDigimonController crée un contrôleur utilisant la source, le
+// catalogue local et le stockage des collections fournis. Les listes des
+// formulaires de filtre sont chargées depuis la source, les listes
+// statiques servant de secours.
+func NewDigimonController(source services.DigimonSource, catalog *services.CatalogStore, collections *services.CollectionStore) *DigimonController {
+	return &DigimonController{
+		source:      source,
+		catalog:     catalog,
+		collections: collections,
+		options: services.NewOptionsProvider(source, services.OptionsConfig{
+			Fallback: services.FilterOptions{
+				Levels:     GetAvailableLevels(),
+				Attributes: GetAvailableAttributes(),
+			},
+		}),
+	}
+}
+
+// createContext crée un contexte avec timeout pour les requêtes API.
+// Il dérive du contexte de la requête : les appels à l'API sont annulés si
+// le client se déconnecte, et le suivi des données périmées est conservé.
+func createContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), 10*time.Second)
+}
+
+// hydrationTimeout borne le temps de récupération des badges d'une liste
+const hydrationTimeout = 3 * time.Second
+
+// maxSuggestions borne le nombre de noms proposés ("vouliez-vous dire")
+const maxSuggestions = 3
+
 // ============================================================
 // AFFICHAGE DE LA LISTE
 // ============================================================
@@ -25,69 +63,88 @@ func createContext() (context.Context, context.CancelFunc) {
 // - Appelle le service qui récupère tous les Digimons
 // - Gère l'erreur éventuelle (service KO / statut != 200)
 // - Rend ensuite le template "list_digimon" avec les données
-func DisplayListDigimons(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayListDigimons(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
-	// Récupère la première page avec une taille généreuse
+	order, err := parseSortOrder(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Page demandée, avec une taille par défaut généreuse
+	req := parsePageRequest(r, 100)
 	opts := &services.DigimonListOptions{
-		PageSize: 100, // Ajustez selon vos besoins
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Sort:     order,
+	}
+
+	source, err := c.listSource(order)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
 	}
 
-	data, dataStatusCode, err := services.GetAllDigimons(ctx, opts)
-	if dataStatusCode != http.StatusOK || err != nil {
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %s", dataStatusCode, err.Error()),
-			dataStatusCode,
-		)
+	data, _, err := source.GetAllDigimons(ctx, opts)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
+	export := map[string]interface{}{"Digimons": c.cards(ctx, data.Content)}
+	pagination := newPagination(r, req, data.TotalElements)
+	templateData := withView(export, map[string]interface{}{
+		"SortLinks":  c.sortLinks(r, order),
+		"Pagination": pagination,
+	})
+
 	// Affiche le template de liste avec les données récupérées
-	helper.RenderTemplate(w, r, "list_digimon", data.Content)
+	helper.RenderPage(w, r, "list_digimon", templateData, export, pagination.meta())
 }
 
 // DisplayListDigimonsWithPagination affiche la liste paginée des Digimons
-func DisplayListDigimonsWithPagination(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayListDigimonsWithPagination(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
-	// Récupère le numéro de page depuis l'URL (ex: ?page=2)
-	pageStr := r.URL.Query().Get("page")
-	page := 0
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
+	// Récupère la page depuis l'URL (ex: ?page=2&pageSize=50)
+	req := parsePageRequest(r, 20)
+
+	order, err := parseSortOrder(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	opts := &services.DigimonListOptions{
-		Page:     page,
-		PageSize: 20,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Sort:     order,
 	}
 
-	data, dataStatusCode, err := services.GetAllDigimons(ctx, opts)
-	if dataStatusCode != http.StatusOK || err != nil {
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %s", dataStatusCode, err.Error()),
-			dataStatusCode,
-		)
+	source, err := c.listSource(order)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
-	// Structure pour le template avec les infos de pagination
-	templateData := map[string]interface{}{
-		"Digimons":     data.Content,
-		"CurrentPage":  page,
-		"TotalPages":   data.TotalPages,
-		"TotalDigimons": data.TotalElements,
-		"HasNext":      !data.Last,
-		"HasPrevious":  !data.First,
+	data, _, err := source.GetAllDigimons(ctx, opts)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
 	}
 
-	helper.RenderTemplate(w, r, "list_digimon_paginated", templateData)
+	// Structure pour le template avec les infos de pagination
+	export := map[string]interface{}{"Digimons": c.cards(ctx, data.Content)}
+	pagination := newPagination(r, req, data.TotalElements)
+	templateData := withView(export, map[string]interface{}{
+		"SortLinks":  c.sortLinks(r, order),
+		"Pagination": pagination,
+	})
+
+	helper.RenderPage(w, r, "list_digimon_paginated", templateData, export, pagination.meta())
 }
 
 // ============================================================
@@ -95,18 +152,25 @@ func DisplayListDigimonsWithPagination(w http.ResponseWriter, r *http.Request) {
 // ============================================================
 
 // DisplaySearch gère la recherche via un champ "query".
-// - Normalise la recherche (trim + lowercase)
+// - Normalise la recherche (trim)
 // - Si vide : redirection vers la liste
-// - Sinon : utilise l'API pour filtrer directement
-func DisplaySearch(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+// - Sinon : recherche tolérante aux fautes dans le catalogue local, ou
+//   recherche par nom de l'API si le catalogue n'est pas chargé
+func (c *DigimonController) DisplaySearch(w http.ResponseWriter, r *http.Request) {
+	c.displaySearch(w, r, false)
+}
+
+// DisplaySearchAdvanced gère la recherche avancée avec recherche exacte
+func (c *DigimonController) DisplaySearchAdvanced(w http.ResponseWriter, r *http.Request) {
+	c.displaySearch(w, r, isChecked(r.FormValue("exact")))
+}
+
+func (c *DigimonController) displaySearch(w http.ResponseWriter, r *http.Request, exact bool) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
 	// Récupère le paramètre de formulaire nommé "query"
-	query := r.FormValue("query")
-
-	// Normalisation : enlever espaces inutiles + passer en minuscules
-	query = strings.TrimSpace(query)
+	query := strings.TrimSpace(r.FormValue("query"))
 
 	// Si pas de recherche, on retourne à la page liste
 	if query == "" {
@@ -114,70 +178,88 @@ func DisplaySearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Utilise l'API pour rechercher directement
-	opts := &services.DigimonListOptions{
-		Name:     query,
-		PageSize: 50,
+	order, err := parseSortOrder(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	data, dataStatusCode, dataError := services.GetAllDigimons(ctx, opts)
-	if dataStatusCode != http.StatusOK || dataError != nil {
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %v", dataStatusCode, dataError.Error()),
-			dataStatusCode,
-		)
+	req := parsePageRequest(r, 50)
+	result, err := c.searchDigimons(ctx, query, exact, order, req)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
-	// Structure pour le template
-	templateData := map[string]interface{}{
-		"Digimons": data.Content,
-		"Query":    query,
-		"Total":    data.TotalElements,
+	// Données exportées (JSON, CSV) puis structure pour le template
+	export := map[string]interface{}{
+		"Digimons":    c.cards(ctx, result.Digimons),
+		"Suggestions": result.Suggestions,
+		"Query":       query,
+		"Exact":       exact,
+		"Total":       result.Total,
 	}
+	pagination := newPagination(r, req, result.Total)
+	templateData := withView(export, map[string]interface{}{
+		"SortLinks":  c.sortLinks(r, order),
+		"Pagination": pagination,
+	})
 
-	// Réutilise le template de liste pour afficher le résultat filtré
-	helper.RenderTemplate(w, r, "search_digimon", templateData)
+	helper.RenderPage(w, r, "search_digimon", templateData, export, pagination.meta())
 }
 
-// DisplaySearchAdvanced gère la recherche avancée avec recherche exacte
-func DisplaySearchAdvanced(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
-	defer cancel()
-
-	query := strings.TrimSpace(r.FormValue("query"))
-	exact := r.FormValue("exact") == "true" || r.FormValue("exact") == "on"
+// searchResult est une page de résultats de recherche
+type searchResult struct {
+	Digimons    []services.DigimonSummary
+	Total       int
+	Suggestions []string // Noms proches, si aucun Digimon ne porte exactement ce nom
+}
 
-	if query == "" {
-		http.Redirect(w, r, "/digimons", http.StatusSeeOther)
-		return
+// searchDigimons recherche une page de Digimons par nom.
+// Avec le catalogue local, la recherche tolère casse, accents et fautes de
+// frappe et classe les résultats par pertinence (sauf tri demandé) ; une
+// recherche exacte ou sans catalogue passe par la source. Les suggestions
+// "vouliez-vous dire" nécessitent le catalogue.
+func (c *DigimonController) searchDigimons(ctx context.Context, query string, exact bool, order services.SortOrder, req pageRequest) (*searchResult, error) {
+	snapshot, hasCatalog := c.catalog.Snapshot()
+
+	if hasCatalog && !exact {
+		hits := snapshot.SearchDigimons(query, order)
+		page := services.Paginate(hits, req.Page, req.PageSize)
+
+		result := &searchResult{
+			Digimons:    make([]services.DigimonSummary, len(page)),
+			Total:       len(hits),
+			Suggestions: snapshot.SearchIndex().Suggest(query, maxSuggestions),
+		}
+		for i, hit := range page {
+			result.Digimons[i] = hit.Digimon
+		}
+		return result, nil
 	}
 
 	opts := &services.DigimonListOptions{
 		Name:     query,
 		Exact:    exact,
-		PageSize: 50,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Sort:     order,
 	}
 
-	data, dataStatusCode, dataError := services.GetAllDigimons(ctx, opts)
-	if dataStatusCode != http.StatusOK || dataError != nil {
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %v", dataStatusCode, dataError.Error()),
-			dataStatusCode,
-		)
-		return
+	source, err := c.listSource(order)
+	if err != nil {
+		return nil, err
 	}
-
-	templateData := map[string]interface{}{
-		"Digimons": data.Content,
-		"Query":    query,
-		"Exact":    exact,
-		"Total":    data.TotalElements,
+	data, _, err := source.GetAllDigimons(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	helper.RenderTemplate(w, r, "search_digimon", templateData)
+	result := &searchResult{Digimons: data.Content, Total: data.TotalElements}
+	if hasCatalog && data.TotalElements == 0 {
+		result.Suggestions = snapshot.SearchIndex().Suggest(query, maxSuggestions)
+	}
+	return result, nil
 }
 
 // ============================================================
@@ -189,8 +271,8 @@ func DisplaySearchAdvanced(w http.ResponseWriter, r *http.Request) {
 // - attribut (champ "attribute")
 // - X-Antibody (checkbox "xantibody")
 // Puis affiche le template "filter_digimons".
-func DisplayFilter(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayFilter(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
 	// Parse le formulaire pour accéder à r.Form
@@ -199,121 +281,103 @@ func DisplayFilter(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Récupère les paramètres de filtrage
-	level := strings.TrimSpace(r.FormValue("level"))
-	attribute := strings.TrimSpace(r.FormValue("attribute"))
-	xAntibodyStr := r.FormValue("xantibody")
-
-	// Debug console
-	log.Printf("Filtres - Level: %s, Attribute: %s, XAntibody: %s", level, attribute, xAntibodyStr)
-
-	// Construction des options de filtrage
-	opts := &services.DigimonListOptions{
-		PageSize: 100,
-	}
-
-	// Filtre par niveau si fourni
-	if level != "" {
-		opts.Level = level
+	// Construction des options de filtrage (niveau, attribut, X-Antibody), du tri et de la page
+	req := parsePageRequest(r, 100)
+	opts := parseFilterOptions(r, req)
+	order, err := parseSortOrder(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
+	opts.Sort = order
 
-	// Filtre par attribut si fourni
-	if attribute != "" {
-		opts.Attribute = attribute
-	}
+	// Debug console
+	log.Printf("Filtres - Level: %s, Attribute: %s, XAntibody: %t", opts.Level, opts.Attribute, opts.XAntibody != nil)
 
-	// Filtre par X-Antibody si coché
-	if xAntibodyStr == "true" || xAntibodyStr == "on" {
-		hasXAntibody := true
-		opts.XAntibody = &hasXAntibody
+	// Appel à l'API (ou au catalogue pour un tri) avec les filtres
+	source, err := c.listSource(order)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
 	}
-
-	// Appel à l'API avec les filtres
-	data, dataStatusCode, dataError := services.GetAllDigimons(ctx, opts)
-	if dataStatusCode != http.StatusOK || dataError != nil {
-		log.Printf("Erreur DisplayFilter - %s", dataError.Error())
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %v", dataStatusCode, dataError.Error()),
-			dataStatusCode,
-		)
+	data, _, dataError := source.GetAllDigimons(ctx, opts)
+	if dataError != nil {
+		renderServiceError(w, r, dataError)
 		return
 	}
 
-	// Structure pour le template
-	templateData := map[string]interface{}{
-		"Digimons":   data.Content,
-		"Level":      level,
-		"Attribute":  attribute,
-		"XAntibody":  xAntibodyStr == "true" || xAntibodyStr == "on",
+	// Données exportées (JSON, CSV) puis structure pour le template
+	export := map[string]interface{}{
+		"Digimons":   c.cards(ctx, data.Content),
+		"Level":      opts.Level,
+		"Attribute":  opts.Attribute,
+		"XAntibody":  opts.XAntibody != nil,
 		"Total":      data.TotalElements,
 		"TotalPages": data.TotalPages,
 	}
+	pagination := newPagination(r, req, data.TotalElements)
+	templateData := withView(export, map[string]interface{}{
+		"SortLinks":  c.sortLinks(r, order),
+		"Pagination": pagination,
+	})
 
 	// Rend un template dédié au filtrage
-	helper.RenderTemplate(w, r, "filter_digimons", templateData)
+	helper.RenderPage(w, r, "filter_digimons", templateData, export, pagination.meta())
 }
 
-// DisplayFilterAdvanced filtre avec filtrage local en mémoire
-// (utile si vous voulez des critères non supportés par l'API)
-func DisplayFilterAdvanced(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
-	defer cancel()
-
+// DisplayFilterAdvanced filtre localement les Digimons complets du catalogue.
+// - Plusieurs valeurs d'un même critère sont combinées en OU
+// - Les différents critères sont combinés en ET
+// - Les compteurs par facette indiquent le nombre de résultats de chaque case
+func (c *DigimonController) DisplayFilterAdvanced(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Erreur parsing formulaire", http.StatusBadRequest)
 		return
 	}
 
-	// Récupère tous les Digimons
-	opts := &services.DigimonListOptions{
-		PageSize: 500, // Grande taille pour tout récupérer
+	// Le filtrage local nécessite le catalogue complet (levels, attributs...)
+	snapshot, ok := c.catalog.Snapshot()
+	if !ok {
+		renderServiceError(w, r, services.ErrCatalogUnavailable)
+		return
 	}
 
-	data, dataStatusCode, dataError := services.GetAllDigimons(ctx, opts)
-	if dataStatusCode != http.StatusOK || dataError != nil {
-		log.Printf("Erreur DisplayFilterAdvanced - %s", dataError.Error())
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %v", dataStatusCode, dataError.Error()),
-			dataStatusCode,
-		)
+	// Paramètres de filtrage local (checkbox multiples) et tri
+	query := parseDigimonQuery(r)
+	order, err := parseSortOrder(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	// Paramètres de filtrage local
-	levels := r.Form["levels"]          // Checkbox multiple de niveaux
-	attributes := r.Form["attributes"]  // Checkbox multiple d'attributs
-	xAntibodyStr := r.FormValue("xantibody")
+	query.Sort = order
 
 	// Debug
-	log.Printf("Filtres - Levels: %v, Attributes: %v, XAntibody: %s", levels, attributes, xAntibodyStr)
-
-	// Liste finale filtrée
-	validDigimons := []services.DigimonSummary{}
-
-	for _, digimon := range data.Content {
-		
-
-		// Vérification niveau (nécessite de récupérer le Digimon complet)
-		// Note: Cette approche nécessiterait des appels API supplémentaires
-		// Pour simplifier, on utilise uniquement les filtres API
-
-		// Filtre simplifié basé sur le nom (exemple)
-		if len(levels) == 0 && len(attributes) == 0 && xAntibodyStr == "" {
-			validDigimons = append(validDigimons, digimon)
-		}
-	}
-
-	templateData := map[string]interface{}{
-		"Digimons":   validDigimons,
-		"Levels":     levels,
-		"Attributes": attributes,
-		"XAntibody":  xAntibodyStr == "true" || xAntibodyStr == "on",
-		"Total":      len(validDigimons),
-	}
-
-	helper.RenderTemplate(w, r, "filter_digimons_advanced", templateData)
+	log.Printf("Filtres - Levels: %v, Attributes: %v, Types: %v, Fields: %v, Skills: %v, XAntibody: %t",
+		query.Levels, query.Attributes, query.Types, query.Fields, query.Skills, query.XAntibody != nil)
+
+	result := services.RunQuery(snapshot.Digimons, query)
+
+	// Le filtrage local renvoie tous les résultats : découpage en pages
+	req := parsePageRequest(r, 50)
+
+	export := map[string]interface{}{
+		"Digimons":   services.Paginate(result.Digimons, req.Page, req.PageSize),
+		"Levels":     query.Levels,
+		"Attributes": query.Attributes,
+		"Types":      query.Types,
+		"Fields":     query.Fields,
+		"Skills":     query.Skills,
+		"XAntibody":  query.XAntibody != nil,
+		"Total":      result.Total,
+		"Facets":     result.Facets,
+	}
+	pagination := newPagination(r, req, result.Total)
+	templateData := withView(export, map[string]interface{}{
+		"SortLinks":  c.sortLinks(r, order),
+		"Pagination": pagination,
+	})
+
+	helper.RenderPage(w, r, "filter_digimons_advanced", templateData, export, pagination.meta())
 }
 
 // ============================================================
@@ -321,8 +385,8 @@ func DisplayFilterAdvanced(w http.ResponseWriter, r *http.Request) {
 // ============================================================
 
 // DisplayDigimonDetails affiche les détails complets d'un Digimon
-func DisplayDigimonDetails(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayDigimonDetails(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
 	// Récupère l'ID depuis l'URL (ex: /digimon/1)
@@ -339,26 +403,40 @@ func DisplayDigimonDetails(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Récupère le Digimon complet
-	digimon, statusCode, err := services.GetDigimonByID(ctx, id)
-	if statusCode != http.StatusOK || err != nil {
-		if statusCode == http.StatusNotFound {
-			http.Error(w, "Digimon non trouvé", http.StatusNotFound)
-		} else {
-			http.Error(
-				w,
-				fmt.Sprintf("Erreur service - code: %d\nmessage: %s", statusCode, err.Error()),
-				statusCode,
-			)
-		}
+	digimon, _, err := c.source.GetDigimonByID(ctx, id)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
-	helper.RenderTemplate(w, r, "digimon_details", digimon)
+	helper.RenderTemplate(w, r, "digimon_details", c.details(r, digimon))
+}
+
+// digimonDetails est le Digimon affiché sur sa page de détails, avec son état
+// dans les collections de l'utilisateur connecté. Seul le Digimon est renvoyé
+// en JSON (?format=json).
+type digimonDetails struct {
+	*services.Digimon
+	LoggedIn    bool                  `json:"-"` // Les collections demandent une connexion
+	Favorite    bool                  `json:"-"`
+	Collections []services.Collection `json:"-"`
+	Redirect    string                `json:"-"` // Page de retour des formulaires de collection
+}
+
+// details prépare la page de détails d'un Digimon
+func (c *DigimonController) details(r *http.Request, digimon *services.Digimon) digimonDetails {
+	details := digimonDetails{Digimon: digimon, Redirect: r.URL.RequestURI()}
+	if user, ok := services.CurrentUser(r.Context()); ok {
+		details.LoggedIn = true
+		details.Favorite = c.collections.IsFavorite(user.ID, digimon.ID)
+		details.Collections = c.collections.List(user.ID)
+	}
+	return details
 }
 
 // DisplayDigimonDetailsByName affiche les détails d'un Digimon par son nom
-func DisplayDigimonDetailsByName(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayDigimonDetailsByName(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
 	name := r.URL.Query().Get("name")
@@ -367,21 +445,13 @@ func DisplayDigimonDetailsByName(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	digimon, statusCode, err := services.GetDigimonByName(ctx, name)
-	if statusCode != http.StatusOK || err != nil {
-		if statusCode == http.StatusNotFound {
-			http.Error(w, "Digimon non trouvé", http.StatusNotFound)
-		} else {
-			http.Error(
-				w,
-				fmt.Sprintf("Erreur service - code: %d\nmessage: %s", statusCode, err.Error()),
-				statusCode,
-			)
-		}
+	digimon, _, err := c.source.GetDigimonByName(ctx, name)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
-	helper.RenderTemplate(w, r, "digimon_details", digimon)
+	helper.RenderTemplate(w, r, "digimon_details", c.details(r, digimon))
 }
 
 // ============================================================
@@ -389,8 +459,8 @@ func DisplayDigimonDetailsByName(w http.ResponseWriter, r *http.Request) {
 // ============================================================
 
 // DisplayDigimonsByAttribute affiche tous les Digimons d'un attribut spécifique
-func DisplayDigimonsByAttribute(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayDigimonsByAttribute(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
 	attributeName := r.URL.Query().Get("attribute")
@@ -400,28 +470,29 @@ func DisplayDigimonsByAttribute(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Récupère l'attribut avec ses Digimons
-	attribute, statusCode, err := services.GetAttributeByName(ctx, attributeName)
-	if statusCode != http.StatusOK || err != nil {
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %s", statusCode, err.Error()),
-			statusCode,
-		)
+	attribute, _, err := c.source.GetAttributeByName(ctx, attributeName)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
-	templateData := map[string]interface{}{
+	// La liste arrive en un bloc : découpage local en pages
+	req := parsePageRequest(r, 50)
+
+	export := map[string]interface{}{
 		"Attribute": attribute.Attribute,
-		"Digimons":  attribute.Digimons,
+		"Digimons":  services.Paginate(attribute.Digimons, req.Page, req.PageSize),
 		"Total":     len(attribute.Digimons),
 	}
+	pagination := newPagination(r, req, len(attribute.Digimons))
+	templateData := withView(export, map[string]interface{}{"Pagination": pagination})
 
-	helper.RenderTemplate(w, r, "digimons_by_attribute", templateData)
+	helper.RenderPage(w, r, "digimons_by_attribute", templateData, export, pagination.meta())
 }
 
 // DisplayDigimonsByLevel affiche tous les Digimons d'un niveau spécifique
-func DisplayDigimonsByLevel(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := createContext()
+func (c *DigimonController) DisplayDigimonsByLevel(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
 	defer cancel()
 
 	levelName := r.URL.Query().Get("level")
@@ -431,30 +502,297 @@ func DisplayDigimonsByLevel(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Récupère le niveau avec ses Digimons
-	level, statusCode, err := services.GetLevelByName(ctx, levelName)
-	if statusCode != http.StatusOK || err != nil {
-		http.Error(
-			w,
-			fmt.Sprintf("Erreur service - code: %d\nmessage: %s", statusCode, err.Error()),
-			statusCode,
-		)
+	level, _, err := c.source.GetLevelByName(ctx, levelName)
+	if err != nil {
+		renderServiceError(w, r, err)
 		return
 	}
 
-	templateData := map[string]interface{}{
+	// La liste arrive en un bloc : découpage local en pages
+	req := parsePageRequest(r, 50)
+
+	export := map[string]interface{}{
 		"Level":    level.Level,
-		"Digimons": level.Digimons,
+		"Digimons": services.Paginate(level.Digimons, req.Page, req.PageSize),
 		"Total":    len(level.Digimons),
 	}
+	pagination := newPagination(r, req, len(level.Digimons))
+	templateData := withView(export, map[string]interface{}{"Pagination": pagination})
+
+	helper.RenderPage(w, r, "digimons_by_level", templateData, export, pagination.meta())
+}
+
+// DisplayDigimonsByType affiche tous les Digimons d'un type spécifique
+func (c *DigimonController) DisplayDigimonsByType(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	typeName := r.URL.Query().Get("type")
+	if typeName == "" {
+		http.Error(w, "Type manquant", http.StatusBadRequest)
+		return
+	}
+
+	// Récupère le type avec ses Digimons
+	digimonType, _, err := c.source.GetTypeByName(ctx, typeName)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages
+	req := parsePageRequest(r, 50)
+
+	export := map[string]interface{}{
+		"Type":     digimonType.Type,
+		"Digimons": services.Paginate(digimonType.Digimons, req.Page, req.PageSize),
+		"Total":    len(digimonType.Digimons),
+	}
+	pagination := newPagination(r, req, len(digimonType.Digimons))
+	templateData := withView(export, map[string]interface{}{"Pagination": pagination})
 
-	helper.RenderTemplate(w, r, "digimons_by_level", templateData)
+	helper.RenderPage(w, r, "digimons_by_type", templateData, export, pagination.meta())
+}
+
+// DisplayDigimonsByField affiche tous les Digimons d'un champ spécifique
+func (c *DigimonController) DisplayDigimonsByField(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	fieldName := r.URL.Query().Get("field")
+	if fieldName == "" {
+		http.Error(w, "Champ manquant", http.StatusBadRequest)
+		return
+	}
+
+	// Récupère le champ avec ses Digimons
+	field, _, err := c.source.GetFieldByName(ctx, fieldName)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages
+	req := parsePageRequest(r, 50)
+
+	export := map[string]interface{}{
+		"Field":    field.Field,
+		"Digimons": services.Paginate(field.Digimons, req.Page, req.PageSize),
+		"Total":    len(field.Digimons),
+	}
+	pagination := newPagination(r, req, len(field.Digimons))
+	templateData := withView(export, map[string]interface{}{"Pagination": pagination})
+
+	helper.RenderPage(w, r, "digimons_by_field", templateData, export, pagination.meta())
+}
+
+// DisplayDigimonsBySkill affiche tous les Digimons qui partagent une compétence
+func (c *DigimonController) DisplayDigimonsBySkill(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	skillName := r.URL.Query().Get("skill")
+	if skillName == "" {
+		http.Error(w, "Compétence manquante", http.StatusBadRequest)
+		return
+	}
+
+	// Récupère la compétence avec ses Digimons
+	skill, _, err := c.source.GetSkillByName(ctx, skillName)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	// La liste arrive en un bloc : découpage local en pages
+	req := parsePageRequest(r, 50)
+
+	export := map[string]interface{}{
+		"Skill":       skill.Skill,
+		"Description": skill.Description,
+		"Digimons":    services.Paginate(skill.Digimons, req.Page, req.PageSize),
+		"Total":       len(skill.Digimons),
+	}
+	pagination := newPagination(r, req, len(skill.Digimons))
+	templateData := withView(export, map[string]interface{}{"Pagination": pagination})
+
+	helper.RenderPage(w, r, "digimons_by_skill", templateData, export, pagination.meta())
 }
 
 // ============================================================
 // UTILITAIRES
 // ============================================================
 
-// GetAvailableLevels retourne la liste des niveaux disponibles pour les filtres
+// withView complète les données exportées d'une page (JSON, CSV) avec les
+// éléments réservés au template HTML (liens de tri, pagination...)
+func withView(export, view map[string]interface{}) map[string]interface{} {
+	templateData := make(map[string]interface{}, len(export)+len(view))
+	maps.Copy(templateData, export)
+	maps.Copy(templateData, view)
+	return templateData
+}
+
+// isChecked indique si une checkbox ou un booléen de formulaire est activé
+func isChecked(value string) bool {
+	return value == "true" || value == "on"
+}
+
+// parseFilterOptions construit les options de filtrage standard
+// à partir des champs "level", "attribute" et "xantibody"
+func parseFilterOptions(r *http.Request, req pageRequest) *services.DigimonListOptions {
+	opts := &services.DigimonListOptions{
+		Level:     strings.TrimSpace(r.FormValue("level")),
+		Attribute: strings.TrimSpace(r.FormValue("attribute")),
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}
+
+	// Filtre par X-Antibody si coché
+	if isChecked(r.FormValue("xantibody")) {
+		hasXAntibody := true
+		opts.XAntibody = &hasXAntibody
+	}
+
+	return opts
+}
+
+// parseDigimonQuery construit la requête locale à partir des checkbox
+// multiples "levels", "attributes", "types", "fields", "skills" et "xantibody".
+// Le formulaire doit avoir été parsé au préalable.
+func parseDigimonQuery(r *http.Request) services.DigimonQuery {
+	query := services.DigimonQuery{
+		Levels:     r.Form["levels"],
+		Attributes: r.Form["attributes"],
+		Types:      r.Form["types"],
+		Fields:     r.Form["fields"],
+		Skills:     r.Form["skills"],
+	}
+
+	if isChecked(r.FormValue("xantibody")) {
+		hasXAntibody := true
+		query.XAntibody = &hasXAntibody
+	}
+
+	return query
+}
+
+// cards complète les résumés d'une liste pour afficher les badges (niveaux,
+// attributs, X-Antibody). Le catalogue local est lu en mémoire s'il est
+// chargé ; sinon les Digimons passent par le cache de la source, avec un
+// nombre borné d'appels simultanés. Le temps consacré reste borné : les
+// cartes non complétées à temps sont affichées sans badges plutôt que de
+// retarder la page. L'ordre des résumés est conservé (le tri est fait par
+// la source).
+func (c *DigimonController) cards(ctx context.Context, summaries []services.DigimonSummary) []services.DigimonCard {
+	hydrator, ok := c.localSource().(services.Hydrator)
+	if !ok {
+		hydrated := make([]services.HydratedDigimon, len(summaries))
+		for i, summary := range summaries {
+			hydrated[i] = services.HydratedDigimon{Summary: summary}
+		}
+		return services.Cards(hydrated)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hydrationTimeout)
+	defer cancel()
+
+	hydrated, err := hydrator.HydrateDigimons(ctx, summaries)
+	if err != nil {
+		log.Printf("Badges - %s", err.Error())
+	}
+	return services.Cards(hydrated)
+}
+
+// listSource retourne la source d'une liste dans l'ordre demandé. Le tri
+// doit porter sur tous les résultats et non sur la seule page affichée :
+// il est appliqué par le catalogue local, avant la pagination. Sans
+// catalogue, seul l'ordre de l'API (par ID croissant) est disponible.
+func (c *DigimonController) listSource(order services.SortOrder) (services.DigimonSource, error) {
+	if order.IsZero() || order == (services.SortOrder{Field: services.SortByID}) {
+		return c.source, nil
+	}
+	if _, ok := c.catalog.Snapshot(); ok {
+		return services.NewSnapshotSource(c.catalog), nil
+	}
+	return nil, fmt.Errorf("tri par %s: %w", order.Field, services.ErrCatalogUnavailable)
+}
+
+// parseSortOrder lit le tri demandé via ?sort= et ?dir=
+func parseSortOrder(r *http.Request) (services.SortOrder, error) {
+	return services.ParseSortOrder(r.FormValue("sort"), r.FormValue("dir"))
+}
+
+// sortLink décrit un lien de tri affiché au-dessus d'une liste
+type sortLink struct {
+	Label  string
+	URL    string
+	Active bool
+	Desc   bool
+}
+
+// sortLabels associe chaque champ de tri à son libellé
+var sortLabels = map[string]string{
+	services.SortByName:      "Nom",
+	services.SortByID:        "ID",
+	services.SortByLevel:     "Niveau",
+	services.SortByAttribute: "Attribut",
+}
+
+// sortLinks construit les liens de tri de la page courante en conservant
+// les autres paramètres (sauf la page, remise à zéro). Le lien du tri
+// actif inverse le sens. Sans catalogue local, aucun tri n'est proposé
+// (voir listSource).
+func (c *DigimonController) sortLinks(r *http.Request, order services.SortOrder) []sortLink {
+	if _, ok := c.catalog.Snapshot(); !ok {
+		return nil
+	}
+
+	links := make([]sortLink, 0, len(services.SortFields))
+	for _, field := range services.SortFields {
+		query := url.Values{}
+		for key, values := range r.Form {
+			if key != "sort" && key != "dir" && key != "page" {
+				query[key] = values
+			}
+		}
+
+		active := order.Field == field
+		query.Set("sort", field)
+		if active && !order.Desc {
+			query.Set("dir", "desc")
+		}
+
+		links = append(links, sortLink{
+			Label:  sortLabels[field],
+			URL:    r.URL.Path + "?" + query.Encode(),
+			Active: active,
+			Desc:   active && order.Desc,
+		})
+	}
+	return links
+}
+
+// localSource retourne le catalogue local s'il est chargé, pour éviter des
+// dizaines d'appels à l'API, et la source injectée sinon
+func (c *DigimonController) localSource() services.DigimonSource {
+	if _, ok := c.catalog.Snapshot(); ok {
+		return services.NewSnapshotSource(c.catalog)
+	}
+	return c.source
+}
+
+// resolveDigimon récupère un Digimon par ID si la valeur est numérique, par nom sinon
+func resolveDigimon(ctx context.Context, source services.DigimonSource, value string) (*services.Digimon, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		digimon, _, err := source.GetDigimonByID(ctx, id)
+		return digimon, err
+	}
+	digimon, _, err := source.GetDigimonByName(ctx, value)
+	return digimon, err
+}
+
+// GetAvailableLevels retourne la liste par défaut des niveaux, utilisée tant
+// que la liste de l'API n'a pas pu être chargée
 func GetAvailableLevels() []string {
 	return []string{
 		"Fresh",
@@ -468,7 +806,8 @@ func GetAvailableLevels() []string {
 	}
 }
 
-// GetAvailableAttributes retourne la liste des attributs disponibles pour les filtres
+// GetAvailableAttributes retourne la liste par défaut des attributs, utilisée
+// tant que la liste de l'API n'a pas pu être chargée
 func GetAvailableAttributes() []string {
 	return []string{
 		"Vaccine",
@@ -480,10 +819,18 @@ func GetAvailableAttributes() []string {
 }
 
 // DisplayFilterForm affiche le formulaire de filtrage avec les options disponibles
-func DisplayFilterForm(w http.ResponseWriter, r *http.Request) {
+func (c *DigimonController) DisplayFilterForm(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	options := c.options.Get(ctx)
+
 	templateData := map[string]interface{}{
-		"Levels":     GetAvailableLevels(),
-		"Attributes": GetAvailableAttributes(),
+		"Levels":     options.Levels,
+		"Attributes": options.Attributes,
+		"Types":      options.Types,
+		"Fields":     options.Fields,
+		"Fallback":   options.Fallback,
 	}
 
 	helper.RenderTemplate(w, r, "filter_form", templateData)
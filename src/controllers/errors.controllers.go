@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"guide/helper"
+	"guide/services"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// ============================================================
+// CORRESPONDANCE ERREURS -> RÉPONSES HTTP
+// ============================================================
+
+// serviceError décrit la réponse renvoyée au client pour une erreur du service
+type serviceError struct {
+	status  int
+	code    string
+	message string
+}
+
+// classifyError associe chaque catégorie d'erreur du service à un code HTTP
+// et à un message destiné à l'utilisateur. Le détail technique n'est jamais
+// exposé : il est seulement journalisé. Seules les erreurs de saisie
+// (ErrInvalidInput, ErrConflict, ErrUnauthorized) sont renvoyées telles quelles.
+func classifyError(err error) serviceError {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return serviceError{http.StatusBadRequest, "invalid_parameter", err.Error()}
+	case errors.Is(err, services.ErrConflict):
+		return serviceError{http.StatusConflict, "conflict", err.Error()}
+	case errors.Is(err, services.ErrUnauthorized):
+		return serviceError{http.StatusUnauthorized, "unauthorized", err.Error()}
+	case errors.Is(err, services.ErrNotFound):
+		return serviceError{http.StatusNotFound, "not_found", "Ressource non trouvée"}
+	case errors.Is(err, services.ErrRateLimited):
+		return serviceError{http.StatusTooManyRequests, "rate_limited",
+			"L'API Digimon reçoit trop de requêtes, réessayez dans quelques instants"}
+	case errors.Is(err, services.ErrTimeout):
+		return serviceError{http.StatusGatewayTimeout, "timeout", "L'API Digimon n'a pas répondu à temps"}
+	case errors.Is(err, services.ErrCatalogUnavailable):
+		return serviceError{http.StatusServiceUnavailable, "catalog_unavailable",
+			"Catalogue local indisponible - lancez une synchronisation avec l'option -sync"}
+	case errors.Is(err, services.ErrUpstreamUnavailable):
+		return serviceError{http.StatusServiceUnavailable, "unavailable", "L'API Digimon est momentanément indisponible"}
+	case errors.Is(err, services.ErrDecode):
+		return serviceError{http.StatusBadGateway, "bad_response", "Réponse inattendue de l'API Digimon"}
+	}
+	return serviceError{http.StatusBadGateway, "upstream_error", "Erreur lors de l'appel à l'API Digimon"}
+}
+
+// renderServiceError affiche une erreur du service sur une page HTML
+func renderServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	e := prepareServiceError(w, r, err)
+	http.Error(w, e.message, e.status)
+}
+
+// renderAPIServiceError convertit une erreur du service en erreur JSON
+func renderAPIServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	e := prepareServiceError(w, r, err)
+	helper.RenderJSONError(w, r, e.status, e.code, e.message)
+}
+
+// prepareServiceError journalise l'erreur et transmet le délai Retry-After
+// éventuellement demandé par l'API
+func prepareServiceError(w http.ResponseWriter, r *http.Request, err error) serviceError {
+	log.Printf("Erreur service - %s %s: %v", r.Method, r.URL.Path, err)
+
+	var upstream *services.UpstreamError
+	if errors.As(err, &upstream) && upstream.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(upstream.RetryAfter.Seconds()))))
+	}
+
+	return classifyError(err)
+}
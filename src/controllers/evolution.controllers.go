@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxEvolutionDepth borne la profondeur demandée via ?depth=
+const maxEvolutionDepth = 5
+
+// ============================================================
+// ÉVOLUTIONS
+// ============================================================
+
+// DisplayDigimonEvolutions affiche l'arbre d'évolution d'un Digimon (?id=&depth=)
+// - Évolutions antérieures au-dessus, évolutions suivantes en dessous
+// - Les Digimons déjà affichés dans une autre branche ne sont pas redéveloppés
+// - Le catalogue local est utilisé s'il est chargé (sans lui, jusqu'à 150 appels)
+func (c *DigimonController) DisplayDigimonEvolutions(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	id, depth, err := parseEvolutionParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	graph, err := services.ResolveEvolutionGraph(ctx, c.localSource(), id, services.EvolutionOptions{
+		MaxDepth: depth,
+	})
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	templateData := map[string]interface{}{
+		"Root":        graph.Node(graph.RootID),
+		"Ancestors":   graph.Ancestors(),
+		"Descendants": graph.Descendants(),
+		"Depth":       depth,
+		"Truncated":   graph.Truncated,
+		"Total":       len(graph.Nodes),
+	}
+
+	helper.RenderTemplate(w, r, "digimon_evolutions", templateData)
+}
+
+// APIDigimonEvolutions renvoie le graphe d'évolution (nœuds et arêtes) en JSON
+func (c *DigimonController) APIDigimonEvolutions(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	id, depth, err := parseEvolutionParams(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	graph, err := services.ResolveEvolutionGraph(ctx, c.localSource(), id, services.EvolutionOptions{
+		MaxDepth: depth,
+	})
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, graph, nil)
+}
+
+// parseEvolutionParams lit l'ID du Digimon racine et la profondeur (3 par défaut)
+func parseEvolutionParams(r *http.Request) (int, int, error) {
+	idStr := r.URL.Query().Get("id")
+	if idStr == "" {
+		return 0, 0, fmt.Errorf("ID manquant")
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ID invalide")
+	}
+
+	depth := 3
+	if d, err := strconv.Atoi(r.URL.Query().Get("depth")); err == nil && d > 0 {
+		depth = min(d, maxEvolutionDepth)
+	}
+
+	return id, depth, nil
+}
+
+// ============================================================
+// CHEMIN D'ÉVOLUTION
+// ============================================================
+
+// DisplayEvolutionPath affiche le plus court chemin d'évolution entre deux
+// Digimons (?from=&to=, par ID ou par nom) et les alternatives de même longueur.
+// L'option ?weighted=on pénalise les évolutions soumises à condition.
+func (c *DigimonController) DisplayEvolutionPath(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	fromParam := strings.TrimSpace(r.FormValue("from"))
+	toParam := strings.TrimSpace(r.FormValue("to"))
+	weighted := isChecked(r.FormValue("weighted"))
+
+	templateData := map[string]interface{}{
+		"FromQuery": fromParam,
+		"ToQuery":   toParam,
+		"Weighted":  weighted,
+	}
+
+	// Sans paramètres : formulaire vide
+	if fromParam == "" || toParam == "" {
+		helper.RenderPage(w, r, "evolution_path", templateData, nil, nil)
+		return
+	}
+
+	result, err := c.findEvolutionPaths(ctx, fromParam, toParam, weighted)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	templateData["Result"] = result
+	helper.RenderPage(w, r, "evolution_path", templateData, result, nil)
+}
+
+// APIEvolutionPath renvoie les plus courts chemins d'évolution en JSON
+func (c *DigimonController) APIEvolutionPath(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	fromParam := strings.TrimSpace(r.FormValue("from"))
+	toParam := strings.TrimSpace(r.FormValue("to"))
+	if fromParam == "" || toParam == "" {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "missing_parameter", "Paramètres from et to requis")
+		return
+	}
+
+	result, err := c.findEvolutionPaths(ctx, fromParam, toParam, isChecked(r.FormValue("weighted")))
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, result, nil)
+}
+
+// findEvolutionPaths résout les deux Digimons puis lance la recherche.
+// Le catalogue local est utilisé s'il est chargé, pour éviter des dizaines
+// d'appels à l'API pendant l'exploration.
+func (c *DigimonController) findEvolutionPaths(ctx context.Context, fromParam, toParam string, weighted bool) (*services.PathResult, error) {
+	source := c.localSource()
+
+	from, err := resolveDigimon(ctx, source, fromParam)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveDigimon(ctx, source, toParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.FindEvolutionPaths(ctx, source, from.ID, to.ID, services.PathOptions{
+		WeightConditions: weighted,
+	})
+}
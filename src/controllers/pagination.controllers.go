@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"guide/helper"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Bornes de la pagination
+const (
+	maxPageSize = 100 // Taille de page maximum acceptée via ?pageSize=
+	pageWindow  = 2   // Pages affichées de part et d'autre de la page courante
+)
+
+// ============================================================
+// PAGE DEMANDÉE
+// ============================================================
+
+// pageRequest est la page demandée via ?page= (à partir de 0) et ?pageSize=
+type pageRequest struct {
+	Page     int
+	PageSize int
+}
+
+// parsePageRequest lit les paramètres page et pageSize.
+// Une valeur absente ou invalide est remplacée par la page 0 et la taille
+// par défaut de la route ; la taille est bornée à maxPageSize.
+func parsePageRequest(r *http.Request, defaultSize int) pageRequest {
+	req := pageRequest{Page: 0, PageSize: defaultSize}
+
+	if page, err := strconv.Atoi(r.FormValue("page")); err == nil && page > 0 {
+		req.Page = page
+	}
+	if size, err := strconv.Atoi(r.FormValue("pageSize")); err == nil && size > 0 {
+		req.PageSize = size
+	}
+	req.PageSize = min(req.PageSize, maxPageSize)
+
+	return req
+}
+
+// ============================================================
+// NAVIGATION
+// ============================================================
+
+// pagination décrit la page affichée et les liens de navigation
+type pagination struct {
+	Page          int // Numéro de page (à partir de 0)
+	Number        int // Numéro affiché de la page (à partir de 1)
+	PageSize      int
+	TotalElements int
+	TotalPages    int
+	HasNext       bool
+	HasPrevious   bool
+	PreviousURL   string
+	NextURL       string
+	Links         []pageLink // Première page, pages proches de la courante, dernière page
+}
+
+// pageLink est un lien vers une page ; Gap marque les pages sautées (…)
+type pageLink struct {
+	Number  int // Numéro affiché (à partir de 1)
+	URL     string
+	Current bool
+	Gap     bool
+}
+
+// newPagination calcule la navigation d'une liste de total éléments.
+// Les liens conservent les autres paramètres de la requête (recherche,
+// filtres, tri).
+func newPagination(r *http.Request, req pageRequest, total int) pagination {
+	totalPages := (total + req.PageSize - 1) / req.PageSize
+
+	p := pagination{
+		Page:          req.Page,
+		Number:        req.Page + 1,
+		PageSize:      req.PageSize,
+		TotalElements: total,
+		TotalPages:    totalPages,
+		HasNext:       req.Page < totalPages-1,
+		HasPrevious:   req.Page > 0,
+	}
+	if p.HasPrevious {
+		p.PreviousURL = pageURL(r, max(0, min(req.Page-1, totalPages-1)), req.PageSize)
+	}
+	if p.HasNext {
+		p.NextURL = pageURL(r, req.Page+1, req.PageSize)
+	}
+
+	if totalPages <= 1 {
+		return p
+	}
+
+	first := max(0, req.Page-pageWindow)
+	last := min(totalPages-1, req.Page+pageWindow)
+
+	if first > 0 {
+		p.Links = append(p.Links, p.link(r, 0))
+		if first > 1 {
+			p.Links = append(p.Links, pageLink{Gap: true})
+		}
+	}
+	for page := first; page <= last; page++ {
+		p.Links = append(p.Links, p.link(r, page))
+	}
+	if last < totalPages-1 {
+		if last < totalPages-2 {
+			p.Links = append(p.Links, pageLink{Gap: true})
+		}
+		p.Links = append(p.Links, p.link(r, totalPages-1))
+	}
+
+	return p
+}
+
+func (p pagination) link(r *http.Request, page int) pageLink {
+	return pageLink{
+		Number:  page + 1,
+		URL:     pageURL(r, page, p.PageSize),
+		Current: page == p.Page,
+	}
+}
+
+// meta convertit la pagination en métadonnées de réponse JSON
+func (p pagination) meta() *helper.APIMeta {
+	return &helper.APIMeta{
+		Page:          p.Page,
+		PageSize:      p.PageSize,
+		TotalElements: p.TotalElements,
+		TotalPages:    p.TotalPages,
+		HasNext:       p.HasNext,
+		HasPrevious:   p.HasPrevious,
+	}
+}
+
+// pageURL construit le lien vers une page en conservant les autres paramètres
+func pageURL(r *http.Request, page, pageSize int) string {
+	query := url.Values{}
+	for key, values := range r.Form {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+
+	return r.URL.Path + "?" + query.Encode()
+}
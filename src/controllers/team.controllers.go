@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// ÉQUIPES
+// ============================================================
+
+// DisplayTeam affiche une équipe et l'analyse de sa composition
+// (/team?name=&ids=1,2,3). L'URL de la page sert de lien de partage.
+func (c *DigimonController) DisplayTeam(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	team, err := parseTeam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	analysis, err := services.AnalyzeTeam(ctx, c.source, team, c.options.Get(ctx).Attributes)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	templateData := map[string]interface{}{
+		"Analysis": analysis,
+		"ShareURL": shareURL(r, "/team", team),
+		"EditURL":  "/team/edit?" + team.Query(),
+	}
+
+	helper.RenderPage(w, r, "team", templateData, analysis, nil)
+}
+
+// DisplayTeamNew affiche le formulaire d'une nouvelle équipe
+func (c *DigimonController) DisplayTeamNew(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	c.renderTeamEdit(w, r, ctx, services.Team{IDs: []int{}}, "")
+}
+
+// DisplayTeamEdit affiche le formulaire de modification d'une équipe
+// (/team/edit?name=&ids=). Les paramètres ?add= (ID ou nom) et ?remove= (ID)
+// modifient l'équipe puis redirigent vers l'URL de l'équipe obtenue : l'état
+// de l'équipe reste toujours dans l'URL.
+func (c *DigimonController) DisplayTeamEdit(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	team, err := parseTeam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	add := strings.TrimSpace(r.URL.Query().Get("add"))
+	remove := strings.TrimSpace(r.URL.Query().Get("remove"))
+
+	switch {
+	case add != "":
+		if team.IsFull() {
+			c.renderTeamEdit(w, r, ctx, team, fmt.Sprintf("L'équipe compte déjà %d Digimons", services.MaxTeamSize))
+			return
+		}
+		digimon, err := resolveDigimon(ctx, c.source, add)
+		if errors.Is(err, services.ErrNotFound) {
+			c.renderTeamEdit(w, r, ctx, team, fmt.Sprintf("Aucun Digimon ne correspond à « %s »", add))
+			return
+		}
+		if err != nil {
+			renderServiceError(w, r, err)
+			return
+		}
+		team = team.With(digimon.ID)
+	case remove != "":
+		id, err := strconv.Atoi(remove)
+		if err != nil {
+			http.Error(w, "ID invalide", http.StatusBadRequest)
+			return
+		}
+		team = team.Without(id)
+	default:
+		c.renderTeamEdit(w, r, ctx, team, "")
+		return
+	}
+
+	http.Redirect(w, r, "/team/edit?"+team.Query(), http.StatusSeeOther)
+}
+
+// APITeam renvoie l'analyse de composition d'une équipe (?name=&ids=1,2,3)
+func (c *DigimonController) APITeam(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := createContext(r)
+	defer cancel()
+
+	team, err := parseTeam(r)
+	if err != nil {
+		helper.RenderJSONError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	analysis, err := services.AnalyzeTeam(ctx, c.source, team, c.options.Get(ctx).Attributes)
+	if err != nil {
+		renderAPIServiceError(w, r, err)
+		return
+	}
+
+	helper.RenderJSON(w, r, http.StatusOK, analysis, nil)
+}
+
+// renderTeamEdit affiche le formulaire d'équipe avec ses membres et un
+// éventuel message d'erreur (ajout impossible...)
+func (c *DigimonController) renderTeamEdit(w http.ResponseWriter, r *http.Request, ctx context.Context, team services.Team, message string) {
+	analysis, err := services.AnalyzeTeam(ctx, c.source, team, c.options.Get(ctx).Attributes)
+	if err != nil {
+		renderServiceError(w, r, err)
+		return
+	}
+
+	templateData := map[string]interface{}{
+		"Analysis": analysis,
+		"ViewURL":  "/team?" + team.Query(),
+		"IDs":      team.IDList(),
+		"Full":     team.IsFull(),
+		"MaxSize":  services.MaxTeamSize,
+		"Error":    message,
+	}
+
+	helper.RenderPage(w, r, "team_edit", templateData, analysis, nil)
+}
+
+// parseTeam lit l'équipe décrite par les paramètres ?name= et ?ids=
+func parseTeam(r *http.Request) (services.Team, error) {
+	return services.ParseTeam(r.URL.Query().Get("name"), r.URL.Query().Get("ids"))
+}
+
+// shareURL construit le lien absolu d'une équipe, à partager tel quel
+func shareURL(r *http.Request, path string, team services.Team) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s%s?%s", scheme, r.Host, path, team.Query())
+}
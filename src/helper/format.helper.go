@@ -0,0 +1,238 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formats de sortie supportés par RenderTemplate
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// mediaTypeFormats associe les types MIME acceptés à un format de sortie
+var mediaTypeFormats = map[string]string{
+	"text/html":             FormatHTML,
+	"application/xhtml+xml": FormatHTML,
+	"application/json":      FormatJSON,
+	"text/csv":              FormatCSV,
+}
+
+// NegotiateFormat choisit le format de réponse.
+// Le paramètre ?format= est prioritaire, puis l'en-tête Accept
+// (avec ses poids q=). HTML est le format par défaut.
+func NegotiateFormat(r *http.Request) string {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case FormatJSON:
+		return FormatJSON
+	case FormatCSV:
+		return FormatCSV
+	case FormatHTML:
+		return FormatHTML
+	}
+
+	best, bestQ := FormatHTML, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		format, ok := mediaTypeFormats[mediaType]
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if qStr, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(qStr, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+
+	return best
+}
+
+// ============================================================
+// EXPORT CSV
+// ============================================================
+
+// RenderCSV écrit les données d'un template au format CSV.
+// - Une map contenant "Digimons" exporte cette liste
+// - Une liste exporte une ligne par élément
+// - Une structure exporte une seule ligne
+// - Une autre map exporte des paires clé/valeur
+func RenderCSV(w http.ResponseWriter, r *http.Request, name string, data interface{}) {
+	rows := csvRows(data)
+
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = escapeCSVCell(cell)
+		}
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.WriteAll(rows)
+	if err := writer.Error(); err != nil {
+		fmt.Println(err)
+		http.Error(w, "Erreur lors de la génération CSV", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+	buffer.WriteTo(w)
+}
+
+func csvRows(data interface{}) [][]string {
+	value := indirect(reflect.ValueOf(data))
+
+	if value.Kind() == reflect.Map {
+		if digimons := value.MapIndex(reflect.ValueOf("Digimons")); digimons.IsValid() {
+			return csvRows(digimons.Interface())
+		}
+		return csvMapRows(value)
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		if value.Len() == 0 {
+			return [][]string{}
+		}
+		header := csvHeader(indirect(value.Index(0)))
+		rows := [][]string{header}
+		for i := 0; i < value.Len(); i++ {
+			rows = append(rows, csvRecord(indirect(value.Index(i))))
+		}
+		return rows
+	case reflect.Struct:
+		return [][]string{csvHeader(value), csvRecord(value)}
+	case reflect.Invalid:
+		return [][]string{}
+	default:
+		return [][]string{{"value"}, {csvValue(value)}}
+	}
+}
+
+// csvMapRows exporte une map quelconque en paires clé/valeur triées
+func csvMapRows(value reflect.Value) [][]string {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+
+	rows := [][]string{{"key", "value"}}
+	for _, key := range keys {
+		rows = append(rows, []string{fmt.Sprint(key), csvValue(value.MapIndex(key))})
+	}
+	return rows
+}
+
+// csvHeader retourne les noms de colonnes (tags json si présents)
+func csvHeader(value reflect.Value) []string {
+	if value.Kind() != reflect.Struct {
+		return []string{"value"}
+	}
+
+	header := []string{}
+	csvColumns(value, func(name string, _ reflect.Value) {
+		header = append(header, name)
+	})
+	return header
+}
+
+// csvRecord retourne les valeurs d'une ligne, dans l'ordre de csvHeader
+func csvRecord(value reflect.Value) []string {
+	if value.Kind() != reflect.Struct {
+		return []string{csvValue(value)}
+	}
+
+	record := []string{}
+	csvColumns(value, func(_ string, field reflect.Value) {
+		record = append(record, csvValue(field))
+	})
+	return record
+}
+
+// csvColumns parcourt les colonnes d'une structure comme encoding/json :
+// champs exportés, hors json:"-", champs des structures embarquées à plat
+func csvColumns(value reflect.Value, visit func(name string, field reflect.Value)) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if embedded := indirect(value.Field(i)); field.Anonymous && embedded.Kind() == reflect.Struct {
+			csvColumns(embedded, visit)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		visit(name, value.Field(i))
+	}
+}
+
+// csvValue convertit une valeur en cellule CSV.
+// Les listes sont jointes par "; " et les structures réduites à leur
+// premier champ texte (ex: DigimonLevel -> "Rookie").
+func csvValue(value reflect.Value) string {
+	value = indirect(value)
+
+	switch value.Kind() {
+	case reflect.Invalid:
+		return ""
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			parts = append(parts, csvValue(value.Index(i)))
+		}
+		return strings.Join(parts, "; ")
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() && value.Field(i).Kind() == reflect.String {
+				return value.Field(i).String()
+			}
+		}
+		return fmt.Sprint(value.Interface())
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+// escapeCSVCell neutralise les cellules qu'un tableur interpréterait comme
+// une formule (=, +, -, @, tabulation, retour chariot en tête) en les
+// préfixant d'une apostrophe
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// indirect déréférence les pointeurs et interfaces
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
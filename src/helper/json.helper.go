@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// APIResponse est l'enveloppe commune à toutes les réponses JSON de l'API
+type APIResponse struct {
+	Data  interface{} `json:"data"`
+	Meta  *APIMeta    `json:"meta,omitempty"`
+	Stale *StaleInfo  `json:"stale,omitempty"`
+	Error *APIError   `json:"error,omitempty"`
+}
+
+// APIMeta contient les informations de pagination d'une réponse
+type APIMeta struct {
+	Page          int  `json:"page"`
+	PageSize      int  `json:"pageSize"`
+	TotalElements int  `json:"totalElements"`
+	TotalPages    int  `json:"totalPages"`
+	HasNext       bool `json:"hasNext"`
+	HasPrevious   bool `json:"hasPrevious"`
+}
+
+// APIError décrit une erreur renvoyée par l'API
+type APIError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RenderJSON écrit les données dans l'enveloppe JSON avec le statut donné
+func RenderJSON(w http.ResponseWriter, r *http.Request, status int, data interface{}, meta *APIMeta) {
+	writeJSON(w, status, APIResponse{Data: data, Meta: meta, Stale: staleInfo(w, r)})
+}
+
+// RenderJSONError écrit une erreur dans l'enveloppe JSON
+func RenderJSONError(w http.ResponseWriter, r *http.Request, status int, code string, message string) {
+	writeJSON(w, status, APIResponse{
+		Error: &APIError{Status: status, Code: code, Message: message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, response APIResponse) {
+	var buffer bytes.Buffer
+
+	// Encodage dans un buffer pour pouvoir renvoyer une 500 propre en cas d'échec
+	if err := json.NewEncoder(&buffer).Encode(response); err != nil {
+		fmt.Println(err)
+		http.Error(w, "Erreur lors de l'encodage JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	buffer.WriteTo(w)
+}
@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"fmt"
+	"guide/services"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Noms utilisés par les sessions et la protection CSRF
+const (
+	SessionCookieName = "guide_session"
+	CSRFFieldName     = "csrf_token"   // Champ caché des formulaires POST
+	CSRFHeaderName    = "X-CSRF-Token" // En-tête équivalent pour les appels JavaScript
+)
+
+// ============================================================
+// COOKIE DE SESSION
+// ============================================================
+
+// SetSessionCookie envoie le cookie de session au navigateur, en remplaçant
+// celui éventuellement déjà prévu pour la réponse (connexion, déconnexion).
+// Le cookie est inaccessible au JavaScript, limité au site (SameSite=Lax) et
+// réservé à HTTPS quand la requête arrive en HTTPS.
+func SetSessionCookie(w http.ResponseWriter, r *http.Request, session *services.Session, sessions *services.SessionStore) {
+	w.Header()["Set-Cookie"] = slices.DeleteFunc(w.Header()["Set-Cookie"], func(cookie string) bool {
+		return strings.HasPrefix(cookie, SessionCookieName+"=")
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    session.ID,
+		Path:     "/",
+		MaxAge:   int(sessions.TTL().Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ClearSessionCookie demande au navigateur d'oublier le cookie de session
+// (déconnexion, session expirée)
+func ClearSessionCookie(w http.ResponseWriter, r *http.Request) {
+	w.Header()["Set-Cookie"] = slices.DeleteFunc(w.Header()["Set-Cookie"], func(cookie string) bool {
+		return strings.HasPrefix(cookie, SessionCookieName+"=")
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ============================================================
+// FONCTIONS DES TEMPLATES
+// ============================================================
+
+// sessionFuncs déclare les fonctions de session des templates. Elles sont
+// propres à chaque visiteur : ces versions vides ne servent qu'au chargement,
+// requestFuncs les remplace à chaque page.
+var sessionFuncs = template.FuncMap{
+	"csrfField":   func() template.HTML { return "" },
+	"currentUser": func() *services.User { return nil },
+}
+
+// requestFuncs retourne les fonctions de session des templates pour une requête :
+//   - csrfField : champ caché du jeton CSRF, à placer dans chaque formulaire
+//     POST. Ouvre la session du visiteur s'il n'en a pas encore. Les
+//     formulaires GET (recherche, filtres) n'en ont pas : le jeton finirait
+//     dans l'URL et l'historique.
+//   - currentUser : utilisateur connecté (nil pour un visiteur anonyme)
+func requestFuncs(r *http.Request) template.FuncMap {
+	return template.FuncMap{
+		"csrfField": func() template.HTML {
+			session, ok := services.StartSession(r.Context())
+			if !ok {
+				return ""
+			}
+			return template.HTML(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+				CSRFFieldName, template.HTMLEscapeString(session.CSRFToken)))
+		},
+		"currentUser": func() *services.User {
+			user, _ := services.CurrentUser(r.Context())
+			return user
+		},
+	}
+}
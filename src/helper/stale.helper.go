@@ -0,0 +1,49 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"guide/services"
+	"html"
+	"net/http"
+	"time"
+)
+
+// StaleInfo signale une réponse construite avec des données périmées,
+// servies depuis le cache pendant une panne de l'API
+type StaleInfo struct {
+	Since time.Time `json:"since"` // Date de la plus ancienne donnée utilisée
+}
+
+// staleInfo retourne les informations de péremption de la requête (nil si
+// les données sont à jour) et ajoute l'en-tête Warning correspondant
+func staleInfo(w http.ResponseWriter, r *http.Request) *StaleInfo {
+	since, stale := services.StaleSince(r.Context())
+	if !stale {
+		return nil
+	}
+
+	w.Header().Set("Warning", `110 - "Response is Stale"`)
+	return &StaleInfo{Since: since}
+}
+
+// injectStaleBanner insère un bandeau d'avertissement au début du contenu
+// de la page (après <main>, ou à défaut après <body>)
+func injectStaleBanner(page []byte, since time.Time) []byte {
+	banner := fmt.Sprintf(
+		"\n<div class=\"stale-banner\" role=\"status\">⚠️ L'API Digimon est indisponible : "+
+			"ces données datent du %s et peuvent ne plus être à jour.</div>\n",
+		html.EscapeString(since.Format("02/01/2006 à 15:04")),
+	)
+
+	for _, tag := range []string{"<main>", "<body>"} {
+		if i := bytes.Index(page, []byte(tag)); i >= 0 {
+			at := i + len(tag)
+			result := make([]byte, 0, len(page)+len(banner))
+			result = append(result, page[:at]...)
+			result = append(result, banner...)
+			return append(result, page[at:]...)
+		}
+	}
+	return append([]byte(banner), page...)
+}
@@ -3,6 +3,7 @@ package helper
 import (
 	"bytes"
 	"fmt"
+	"guide/services"
 	"html/template"
 	"log"
 	"net/http"
@@ -14,7 +15,8 @@ var listeTemplate *template.Template
 // Load charge tous les fichiers HTML depuis le dossier ../templates
 func Load() {
 	// Chargement des fichiers .html dans le dossier templates
-	temp, tempErr := template.ParseGlob("../templates/*.html")
+	// (les fonctions de session sont liées à chaque page, voir requestFuncs)
+	temp, tempErr := template.New("").Funcs(sessionFuncs).ParseGlob("../templates/*.html")
 	if tempErr != nil {
 		// En cas d'erreur, le programme s'arrête avec un message d'erreur
 		log.Fatalf("Erreur template - %s", tempErr.Error())
@@ -25,12 +27,44 @@ func Load() {
 	fmt.Println("Template - chargement des templates terminé")
 }
 
-// RenderTemplate exécute le template spécifié et écrit le résultat dans la réponse HTTP
+// RenderTemplate exécute le template spécifié et écrit le résultat dans la réponse HTTP.
+// Selon ?format= ou l'en-tête Accept, les mêmes données peuvent être renvoyées
+// en JSON ou en CSV au lieu du HTML : data ne doit donc contenir que des
+// données (voir RenderPage sinon).
 func RenderTemplate(w http.ResponseWriter, r *http.Request, name string, data interface{}) {
+	RenderPage(w, r, name, data, data, nil)
+}
+
+// RenderPage exécute le template spécifié avec view. En JSON ou en CSV, seul
+// export est renvoyé : les éléments propres à la page HTML (liens de tri et de
+// pagination, redirections, champs de formulaire...) restent hors des exports.
+// La pagination éventuelle accompagne le JSON dans meta.
+func RenderPage(w http.ResponseWriter, r *http.Request, name string, view, export interface{}, meta *APIMeta) {
+	// Le contenu dépend de l'en-tête Accept : à signaler aux caches
+	w.Header().Add("Vary", "Accept")
+
+	switch NegotiateFormat(r) {
+	case FormatJSON:
+		RenderJSON(w, r, http.StatusOK, export, meta)
+		return
+	case FormatCSV:
+		staleInfo(w, r)
+		RenderCSV(w, r, name, export)
+		return
+	}
+
 	var buffer bytes.Buffer
 
+	// Copie des templates liée au visiteur (jeton CSRF, utilisateur connecté)
+	pageTemplate, errClone := listeTemplate.Clone()
+	if errClone != nil {
+		fmt.Println(errClone)
+		http.Error(w, "Erreur lors du chargement du template", http.StatusInternalServerError)
+		return
+	}
+
 	// Exécution du template avec les données fournies
-	errRender := listeTemplate.ExecuteTemplate(&buffer, name, data)
+	errRender := pageTemplate.Funcs(requestFuncs(r)).ExecuteTemplate(&buffer, name, view)
 	if errRender != nil {
 		// Si une erreur survient, on retourne une erreur 500 au client
 		fmt.Println(errRender)
@@ -38,6 +72,17 @@ func RenderTemplate(w http.ResponseWriter, r *http.Request, name string, data in
 		return
 	}
 
+	// Bandeau d'avertissement si des données périmées ont été utilisées
+	page := buffer.Bytes()
+	if stale := staleInfo(w, r); stale != nil {
+		page = injectStaleBanner(page, stale.Since)
+	}
+
+	// Page propre au visiteur (jeton CSRF, liens du compte) : pas de cache partagé
+	if _, ok := services.SessionFrom(r.Context()); ok {
+		w.Header().Set("Cache-Control", "private, no-cache")
+	}
+
 	// Écriture du contenu généré dans la réponse HTTP
-	buffer.WriteTo(w)
+	w.Write(page)
 }
\ No newline at end of file
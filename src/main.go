@@ -1,22 +1,91 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"guide/helper"
 	"guide/routes"
+	"guide/services"
+	"log"
 	"net/http"
+	"time"
 )
 
 func main() {
+	// Options de lancement (permettent de pointer vers une API locale)
+	apiURL := flag.String("api-url", services.DefaultAPIBaseURL, "URL de base de l'API Digimon")
+	apiTimeout := flag.Duration("api-timeout", 10*time.Second, "Timeout des requêtes vers l'API")
+	apiRate := flag.Float64("api-rate", 5, "Nombre maximum de requêtes par seconde vers l'API")
+	apiBurst := flag.Int("api-burst", 10, "Nombre de requêtes pouvant partir d'un coup vers l'API")
+	apiRetries := flag.Int("api-retries", 3, "Nombre de tentatives par requête vers l'API (1 = aucun nouvel essai)")
+	snapshotPath := flag.String("snapshot", "../data/catalog.json", "Fichier du snapshot local du catalogue")
+	offline := flag.Bool("offline", false, "Sert uniquement les données du snapshot, sans accès à l'API")
+	collectionsPath := flag.String("collections", "../data/collections.json", "Fichier de stockage des favoris et collections")
+	usersPath := flag.String("users", "../data/users.json", "Fichier de stockage des comptes utilisateurs")
+	sessionTTL := flag.Duration("session-ttl", services.DefaultSessionTTL, "Durée de vie d'une session inactive")
+	syncOnly := flag.Bool("sync", false, "Synchronise tout le catalogue dans le snapshot puis quitte")
+	flag.Parse()
+
+	// Création de la source de données Digimon, avec un cache mémoire devant l'API
+	var source services.DigimonSource = services.NewCachedSource(
+		services.NewHTTPSource(services.HTTPSourceConfig{
+			BaseURL: *apiURL,
+			Timeout: *apiTimeout,
+			Retry:   services.RetryPolicy{MaxAttempts: *apiRetries},
+			Limiter: services.LimiterConfig{Rate: *apiRate, Burst: *apiBurst},
+		}),
+		services.CacheConfig{},
+	)
+
+	// Catalogue local (snapshot sur disque)
+	catalog := services.NewCatalogStore(*snapshotPath)
+
+	// Mode synchronisation : récupère tout le catalogue puis s'arrête
+	if *syncOnly {
+		if *offline {
+			log.Fatal("Sync - impossible de synchroniser en mode hors-ligne")
+		}
+		err := catalog.Sync(context.Background(), source, services.SyncOptions{Source: *apiURL})
+		if err != nil {
+			log.Fatalf("Sync - %s", err.Error())
+		}
+		fmt.Printf("Sync - snapshot écrit dans %s\n", catalog.Path())
+		return
+	}
+
+	if err := catalog.Load(); err != nil {
+		if *offline {
+			log.Fatalf("Catalogue - mode hors-ligne impossible: %s", err.Error())
+		}
+		log.Printf("Catalogue - snapshot non chargé: %s", err.Error())
+	}
+
+	// En mode hors-ligne, toutes les lectures passent par le snapshot
+	if *offline {
+		source = services.NewSnapshotSource(catalog)
+		fmt.Println("Mode hors-ligne - données servies depuis", catalog.Path())
+	}
+
+	// Favoris et collections (fichier créé à la première modification)
+	collections := services.NewCollectionStore(*collectionsPath)
+	if err := collections.Load(); err != nil {
+		log.Fatalf("Collections - %s", err.Error())
+	}
+
+	// Comptes utilisateurs (sur disque) et sessions (en mémoire)
+	users := services.NewUserStore(*usersPath)
+	if err := users.Load(); err != nil {
+		log.Fatalf("Utilisateurs - %s", err.Error())
+	}
+	sessions := services.NewSessionStore(*sessionTTL)
+
 	// Chargement des templates
 	helper.Load()
 	// Chargement des routes du serveur
-	serveRouter := routes.MainRouter()
+	serveRouter := routes.MainRouter(source, catalog, collections, users, sessions)
 	// Message d'information indiquant que le serveur est lancé
 	fmt.Println("Serveur lancé : http://localhost:8080")
 	// Lancement du serveur HTTP sur le port 8080
 	http.ListenAndServe("localhost:8080", serveRouter)
 }
-
-
-
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"guide/controllers"
+	"net/http"
+)
+
+// accountRoutes configure les pages et l'API des comptes utilisateurs
+func accountRoutes(router *http.ServeMux, accounts *controllers.AccountController) {
+	// Inscription et connexion (formulaire en GET, envoi en POST)
+	router.HandleFunc("/account/register", accounts.DisplayRegister)
+	router.HandleFunc("/account/login", accounts.DisplayLogin)
+
+	// Déconnexion (POST uniquement)
+	router.HandleFunc("/account/logout", accounts.Logout)
+
+	// Page du compte connecté
+	router.HandleFunc("/account", accounts.DisplayAccount)
+
+	// Utilisateur connecté, en JSON
+	router.HandleFunc("/api/v1/account/me", accounts.APIMe)
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"guide/controllers"
+	"net/http"
+)
+
+// apiRoutes configure l'API JSON versionnée, miroir des routes HTML.
+// Les paramètres de requête sont identiques à ceux des pages équivalentes.
+func apiRoutes(router *http.ServeMux, digimons *controllers.DigimonController) {
+	// Liste et pagination
+	router.HandleFunc("/api/v1/digimons", digimons.APIListDigimons)
+	router.HandleFunc("/api/v1/digimons/paginated", digimons.APIListDigimonsWithPagination)
+
+	// Recherche
+	router.HandleFunc("/api/v1/digimons/search", digimons.APISearch)
+	router.HandleFunc("/api/v1/digimons/search/advanced", digimons.APISearchAdvanced)
+
+	// Autocomplétion du champ de recherche
+	router.HandleFunc("/api/suggest", digimons.APISuggest)
+
+	// Filtrage
+	router.HandleFunc("/api/v1/digimons/filter", digimons.APIFilter)
+	router.HandleFunc("/api/v1/digimons/filter/advanced", digimons.APIFilterAdvanced)
+	router.HandleFunc("/api/v1/options", digimons.APIFilterOptions)
+
+	// Détails
+	router.HandleFunc("/api/v1/digimon/details", digimons.APIDigimonDetails)
+	router.HandleFunc("/api/v1/digimon/details/name", digimons.APIDigimonDetailsByName)
+	router.HandleFunc("/api/v1/digimon/evolutions", digimons.APIDigimonEvolutions)
+	router.HandleFunc("/api/v1/digimons/path", digimons.APIEvolutionPath)
+	router.HandleFunc("/api/v1/digimons/compare", digimons.APICompare)
+	router.HandleFunc("/api/v1/battle", digimons.APIBattle)
+	router.HandleFunc("/api/v1/team", digimons.APITeam)
+
+	// Par ressources
+	router.HandleFunc("/api/v1/digimons/by-attribute", digimons.APIDigimonsByAttribute)
+	router.HandleFunc("/api/v1/digimons/by-level", digimons.APIDigimonsByLevel)
+	router.HandleFunc("/api/v1/digimons/by-type", digimons.APIDigimonsByType)
+	router.HandleFunc("/api/v1/digimons/by-field", digimons.APIDigimonsByField)
+	router.HandleFunc("/api/v1/digimons/by-skill", digimons.APIDigimonsBySkill)
+
+	// Supervision
+	router.HandleFunc("/api/v1/stats", digimons.APIStats)
+}
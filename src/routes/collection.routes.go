@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"guide/controllers"
+	"net/http"
+)
+
+// collectionsRoutes configure les pages et l'API des favoris et collections.
+// Chaque utilisateur connecté gère ses propres collections : les visiteurs
+// anonymes sont renvoyés vers la connexion (401 pour l'API). Les
+// modifications (création, ajout, retrait, import...) n'acceptent que POST.
+func collectionsRoutes(router *http.ServeMux, digimons *controllers.DigimonController) {
+	// ============================================================
+	// PAGES
+	// ============================================================
+
+	// Liste des collections, formulaires de création et d'import
+	router.HandleFunc("/collections", digimons.DisplayCollections)
+
+	// Digimons d'une collection (?slug=), paginés
+	router.HandleFunc("/collections/view", digimons.DisplayCollection)
+
+	// Téléchargement d'une collection en JSON (?slug=)
+	router.HandleFunc("/collections/export", digimons.ExportCollection)
+
+	// Actions des formulaires (redirigent vers la page concernée)
+	router.HandleFunc("/collections/create", digimons.CreateCollection)
+	router.HandleFunc("/collections/delete", digimons.DeleteCollection)
+	router.HandleFunc("/collections/add", digimons.AddToCollection)
+	router.HandleFunc("/collections/remove", digimons.RemoveFromCollection)
+	router.HandleFunc("/collections/import", digimons.ImportCollection)
+
+	// ============================================================
+	// API JSON
+	// ============================================================
+
+	router.HandleFunc("/api/v1/collections", digimons.APICollections)
+	router.HandleFunc("/api/v1/collections/view", digimons.APICollection)
+	router.HandleFunc("/api/v1/collections/create", digimons.APICreateCollection)
+	router.HandleFunc("/api/v1/collections/delete", digimons.APIDeleteCollection)
+	router.HandleFunc("/api/v1/collections/add", digimons.APIAddToCollection)
+	router.HandleFunc("/api/v1/collections/remove", digimons.APIRemoveFromCollection)
+	router.HandleFunc("/api/v1/collections/import", digimons.APIImportCollection)
+}
@@ -6,57 +6,91 @@ import (
 )
 
 // digimonsRoutes configure toutes les routes liées aux Digimons
-func digimonsRoutes(router *http.ServeMux) {
+func digimonsRoutes(router *http.ServeMux, digimons *controllers.DigimonController) {
 	// ============================================================
 	// LISTE ET PAGINATION
 	// ============================================================
 	
 	// Liste complète des Digimons (première page)
-	router.HandleFunc("/digimons", controllers.DisplayListDigimons)
+	router.HandleFunc("/digimons", digimons.DisplayListDigimons)
 	
 	// Liste paginée des Digimons avec navigation
-	router.HandleFunc("/digimons/paginated", controllers.DisplayListDigimonsWithPagination)
+	router.HandleFunc("/digimons/paginated", digimons.DisplayListDigimonsWithPagination)
 
 	// ============================================================
 	// RECHERCHE
 	// ============================================================
 	
 	// Recherche simple par nom
-	router.HandleFunc("/digimons/search", controllers.DisplaySearch)
+	router.HandleFunc("/digimons/search", digimons.DisplaySearch)
 	
 	// Recherche avancée (avec option exacte)
-	router.HandleFunc("/digimons/search/advanced", controllers.DisplaySearchAdvanced)
+	router.HandleFunc("/digimons/search/advanced", digimons.DisplaySearchAdvanced)
 
 	// ============================================================
 	// FILTRAGE
 	// ============================================================
 	
 	// Formulaire de filtrage
-	router.HandleFunc("/digimons/filter/form", controllers.DisplayFilterForm)
+	router.HandleFunc("/digimons/filter/form", digimons.DisplayFilterForm)
 	
 	// Filtrage standard (niveau, attribut, X-Antibody)
-	router.HandleFunc("/digimons/filter", controllers.DisplayFilter)
+	router.HandleFunc("/digimons/filter", digimons.DisplayFilter)
 	
 	// Filtrage avancé (avec filtres multiples en mémoire)
-	router.HandleFunc("/digimons/filter/advanced", controllers.DisplayFilterAdvanced)
+	router.HandleFunc("/digimons/filter/advanced", digimons.DisplayFilterAdvanced)
 
 	// ============================================================
 	// DÉTAILS
 	// ============================================================
 	
 	// Détails d'un Digimon par ID
-	router.HandleFunc("/digimon/details", controllers.DisplayDigimonDetails)
+	router.HandleFunc("/digimon/details", digimons.DisplayDigimonDetails)
 	
 	// Détails d'un Digimon par nom
-	router.HandleFunc("/digimon/details/name", controllers.DisplayDigimonDetailsByName)
+	router.HandleFunc("/digimon/details/name", digimons.DisplayDigimonDetailsByName)
+
+	// Arbre d'évolution d'un Digimon
+	router.HandleFunc("/digimon/evolutions", digimons.DisplayDigimonEvolutions)
+
+	// Plus court chemin d'évolution entre deux Digimons
+	router.HandleFunc("/digimons/path", digimons.DisplayEvolutionPath)
+
+	// Comparaison côte à côte de plusieurs Digimons (?ids=1,2,3)
+	router.HandleFunc("/digimons/compare", digimons.DisplayCompare)
+
+	// Simulation de combat entre deux Digimons (?a=&b=, ID ou nom)
+	router.HandleFunc("/battle", digimons.DisplayBattle)
+
+	// ============================================================
+	// ÉQUIPES
+	// ============================================================
+
+	// Analyse d'une équipe (?name=&ids=1,2,3), URL partageable
+	router.HandleFunc("/team", digimons.DisplayTeam)
+
+	// Création d'une nouvelle équipe
+	router.HandleFunc("/team/new", digimons.DisplayTeamNew)
+
+	// Modification d'une équipe (?add= et ?remove= redirigent vers l'équipe obtenue)
+	router.HandleFunc("/team/edit", digimons.DisplayTeamEdit)
 
 	// ============================================================
 	// PAR RESSOURCES
 	// ============================================================
 	
 	// Liste des Digimons par attribut (Vaccine, Virus, Data, etc.)
-	router.HandleFunc("/digimons/by-attribute", controllers.DisplayDigimonsByAttribute)
+	router.HandleFunc("/digimons/by-attribute", digimons.DisplayDigimonsByAttribute)
 	
 	// Liste des Digimons par niveau (Rookie, Champion, Ultimate, etc.)
-	router.HandleFunc("/digimons/by-level", controllers.DisplayDigimonsByLevel)
+	router.HandleFunc("/digimons/by-level", digimons.DisplayDigimonsByLevel)
+
+	// Liste des Digimons par type (Reptile, Dragon, etc.)
+	router.HandleFunc("/digimons/by-type", digimons.DisplayDigimonsByType)
+
+	// Liste des Digimons par champ (Nature Spirits, Virus Busters, etc.)
+	router.HandleFunc("/digimons/by-field", digimons.DisplayDigimonsByField)
+
+	// Liste des Digimons partageant une compétence
+	router.HandleFunc("/digimons/by-skill", digimons.DisplayDigimonsBySkill)
 }
\ No newline at end of file
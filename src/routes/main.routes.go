@@ -1,17 +1,30 @@
 package routes
 
 import (
+	"guide/controllers"
+	"guide/services"
 	"net/http"
 )
 
-// MainRouter initialise et retourne le routeur principal de l'application
-func MainRouter() *http.ServeMux {
+// MainRouter initialise et retourne le routeur principal de l'application.
+// La source de données, le catalogue local, les collections, les comptes et
+// les sessions sont injectés dans les contrôleurs qui en ont besoin.
+func MainRouter(source services.DigimonSource, catalog *services.CatalogStore, collections *services.CollectionStore,
+	users *services.UserStore, sessions *services.SessionStore) http.Handler {
 
 	// Création du routeur principal
 	mainRouter := http.NewServeMux()
 
-	// Enregistrement des routes Digimon
-	digimonsRoutes(mainRouter)
+	// Enregistrement des routes Digimon (pages HTML et API JSON)
+	digimons := controllers.NewDigimonController(source, catalog, collections)
+	digimonsRoutes(mainRouter, digimons)
+	apiRoutes(mainRouter, digimons)
+
+	// Enregistrement des routes des collections (favoris compris)
+	collectionsRoutes(mainRouter, digimons)
+
+	// Enregistrement des routes des comptes utilisateurs
+	accountRoutes(mainRouter, controllers.NewAccountController(users, sessions))
 	
 	// Routes de test (si vous en avez besoin)
 	testRoutes(mainRouter)
@@ -22,5 +35,6 @@ func MainRouter() *http.ServeMux {
 	// Route permettant de servir les fichiers statiques via /static/
 	mainRouter.Handle("/static/", http.StripPrefix("/static/", fileServerHandler))
 
-	return mainRouter
+	// Chaque requête reçoit sa session (utilisateur connecté, jeton CSRF)
+	return withStaleTracking(withSessions(mainRouter, sessions, users))
 }
\ No newline at end of file
@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"guide/helper"
+	"guide/services"
+	"net/http"
+	"strings"
+)
+
+// withStaleTracking prépare chaque requête au signalement des données
+// périmées servies depuis le cache (bandeau HTML, champ "stale" en JSON)
+func withStaleTracking(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(services.WithStaleTracker(r.Context())))
+	})
+}
+
+// maxFormBytes borne le corps lu pour vérifier le jeton CSRF d'un formulaire
+const maxFormBytes = 2 << 20
+
+// withSessions attache à chaque requête la session du visiteur et
+// l'utilisateur connecté, puis vérifie le jeton CSRF des requêtes qui
+// modifient des données (POST, PUT, PATCH, DELETE) : sans session valide et
+// jeton correspondant, elles sont refusées, connexion comprise.
+// Une session n'est ouverte qu'à la connexion ou au premier formulaire qui
+// demande un jeton : l'API, les fichiers statiques et les robots n'en
+// créent pas.
+func withSessions(next http.Handler, sessions *services.SessionStore, users *services.UserStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var session *services.Session
+		if cookie, err := r.Cookie(helper.SessionCookieName); err == nil {
+			var ok bool
+			if session, ok = sessions.Get(cookie.Value); ok {
+				// Renvoyé à chaque réponse : prolonge aussi la durée du cookie
+				helper.SetSessionCookie(w, r, session, sessions)
+			} else {
+				helper.ClearSessionCookie(w, r)
+			}
+		}
+
+		if !isSafeMethod(r.Method) && !validCSRFToken(w, r, session) {
+			if strings.HasPrefix(r.URL.Path, "/api/") {
+				helper.RenderJSONError(w, r, http.StatusForbidden, "csrf_invalid", "Session ou jeton CSRF manquant ou invalide")
+				return
+			}
+			http.Error(w, "Jeton CSRF manquant ou invalide, rechargez la page", http.StatusForbidden)
+			return
+		}
+
+		ctx := services.WithSession(r.Context(), session, func() *services.Session {
+			session := sessions.Start()
+			helper.SetSessionCookie(w, r, session, sessions)
+			return session
+		})
+		if session != nil && session.Authenticated() {
+			if user, ok := users.Get(session.UserID); ok {
+				ctx = services.WithUser(ctx, user)
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isSafeMethod indique si la méthode HTTP est en lecture seule
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// validCSRFToken compare le jeton envoyé (en-tête, puis champ de formulaire)
+// à celui de la session, en temps constant
+func validCSRFToken(w http.ResponseWriter, r *http.Request, session *services.Session) bool {
+	if session == nil {
+		return false
+	}
+
+	token := r.Header.Get(helper.CSRFHeaderName)
+	if token == "" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+		token = r.FormValue(helper.CSRFFieldName)
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(session.CSRFToken)) == 1
+}
@@ -0,0 +1,103 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guide/helper"
+	"guide/services"
+)
+
+func TestWithSessionsCSRF(t *testing.T) {
+	sessions := services.NewSessionStore(0)
+	users := services.NewUserStore(filepath.Join(t.TempDir(), "users.json"))
+	session := sessions.Start()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		cookie     bool   // Envoie le cookie de la session
+		field      string // Jeton du champ de formulaire
+		header     string // Jeton de l'en-tête
+		wantStatus int
+	}{
+		{name: "GET sans session", method: http.MethodGet, path: "/collections", wantStatus: http.StatusOK},
+		{name: "GET avec session sans jeton", method: http.MethodGet, path: "/collections", cookie: true, wantStatus: http.StatusOK},
+		{name: "HEAD sans jeton", method: http.MethodHead, path: "/collections", cookie: true, wantStatus: http.StatusOK},
+		{name: "POST sans session", method: http.MethodPost, path: "/collections/add", field: session.CSRFToken, wantStatus: http.StatusForbidden},
+		{name: "POST sans jeton", method: http.MethodPost, path: "/collections/add", cookie: true, wantStatus: http.StatusForbidden},
+		{name: "POST avec un jeton différent", method: http.MethodPost, path: "/collections/add", cookie: true, field: "autre", wantStatus: http.StatusForbidden},
+		{name: "POST avec le jeton du formulaire", method: http.MethodPost, path: "/collections/add", cookie: true, field: session.CSRFToken, wantStatus: http.StatusOK},
+		{name: "POST avec le jeton de l'en-tête", method: http.MethodPost, path: "/api/collections/add", cookie: true, header: session.CSRFToken, wantStatus: http.StatusOK},
+		{name: "DELETE sans jeton", method: http.MethodDelete, path: "/api/collections", cookie: true, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+			handler := withSessions(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+			}), sessions, users)
+
+			form := url.Values{}
+			if tt.field != "" {
+				form.Set(helper.CSRFFieldName, tt.field)
+			}
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie {
+				r.AddCookie(&http.Cookie{Name: helper.SessionCookieName, Value: session.ID})
+			}
+			if tt.header != "" {
+				r.Header.Set(helper.CSRFHeaderName, tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("statut = %d, attendu %d", w.Code, tt.wantStatus)
+			}
+			if reached != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("gestionnaire appelé = %t, attendu %t", reached, !reached)
+			}
+		})
+	}
+}
+
+func TestWithSessionsCSRFAPIError(t *testing.T) {
+	sessions := services.NewSessionStore(0)
+	users := services.NewUserStore(filepath.Join(t.TempDir(), "users.json"))
+	handler := withSessions(http.NotFoundHandler(), sessions, users)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/collections/add", nil))
+
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "csrf_invalid") {
+		t.Errorf("réponse = %d %s, attendu 403 csrf_invalid", w.Code, w.Body.String())
+	}
+}
+
+func TestWithSessionsExpiredCookie(t *testing.T) {
+	sessions := services.NewSessionStore(0)
+	users := services.NewUserStore(filepath.Join(t.TempDir(), "users.json"))
+	handler := withSessions(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := services.SessionFrom(r.Context()); ok {
+			t.Error("session attachée pour un cookie inconnu")
+		}
+	}), sessions, users)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: helper.SessionCookieName, Value: "inconnu"})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if cookie := w.Header().Get("Set-Cookie"); !strings.Contains(cookie, helper.SessionCookieName+"=;") {
+		t.Errorf("Set-Cookie = %q, attendu l'effacement du cookie de session", cookie)
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen est retournée sans appeler l'API tant que le disjoncteur
+// est ouvert. Elle est classée comme ErrUpstreamUnavailable.
+var ErrCircuitOpen = fmt.Errorf("disjoncteur ouvert: %w", ErrUpstreamUnavailable)
+
+// ============================================================
+// DISJONCTEUR
+// ============================================================
+
+// BreakerConfig paramètre le disjoncteur de l'API
+type BreakerConfig struct {
+	FailureThreshold int           // Échecs consécutifs avant ouverture (défaut: 5)
+	OpenTimeout      time.Duration // Durée d'ouverture avant un appel de test (défaut: 30s)
+}
+
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // Appels transmis normalement
+	breakerOpen                         // Appels refusés immédiatement
+	breakerHalfOpen                     // Un seul appel de test autorisé
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "ouvert"
+	case breakerHalfOpen:
+		return "semi-ouvert"
+	}
+	return "fermé"
+}
+
+// CircuitBreaker coupe les appels à l'API après plusieurs échecs consécutifs.
+// Pendant OpenTimeout, les appels échouent immédiatement avec ErrCircuitOpen
+// au lieu d'attendre le timeout ; ensuite un appel de test décide de la
+// refermeture (succès) ou d'une nouvelle période d'ouverture (échec).
+type CircuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker crée un disjoncteur fermé
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+
+	return &CircuitBreaker{
+		threshold:   cfg.FailureThreshold,
+		openTimeout: cfg.OpenTimeout,
+	}
+}
+
+// Allow indique si un appel peut être tenté (nil) ou doit être refusé
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.setState(breakerHalfOpen)
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record enregistre le résultat d'un appel autorisé par Allow.
+// Seules les pannes de l'API comptent comme échecs : un 404 ne rapproche
+// pas l'ouverture.
+// Une requête annulée par le client ou retenue par le limiteur local est
+// neutre : elle n'a pas atteint l'API (ou n'a pas attendu sa réponse), ni les
+// compteurs ni l'état ne changent (un appel de test neutre laisse simplement
+// la place au suivant).
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrThrottled) {
+		return
+	}
+
+	if !isRetryable(err) {
+		b.failures = 0
+		if b.state != breakerClosed {
+			b.setState(breakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		if b.state != breakerOpen {
+			b.setState(breakerOpen)
+		}
+	}
+}
+
+// State retourne l'état courant du disjoncteur (fermé, ouvert, semi-ouvert)
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state.String()
+}
+
+// setState change l'état en journalisant la transition (verrou déjà pris)
+func (b *CircuitBreaker) setState(state breakerState) {
+	log.Printf("API - disjoncteur %s -> %s", b.state, state)
+	b.state = state
+}
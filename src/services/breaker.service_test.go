@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// failure est une panne de l'API, comptée comme échec par le disjoncteur
+var failure = &UpstreamError{Kind: ErrUpstreamUnavailable, StatusCode: 503}
+
+// record fait passer un appel par le disjoncteur
+func record(t *testing.T, breaker *CircuitBreaker, err error) {
+	t.Helper()
+	if allowErr := breaker.Allow(); allowErr != nil {
+		t.Fatalf("Allow() = %v, appel attendu autorisé", allowErr)
+	}
+	breaker.Record(err)
+}
+
+func TestCircuitBreakerRecord(t *testing.T) {
+	canceled := fmt.Errorf("%w: %w", ErrUpstreamUnavailable, context.Canceled)
+
+	tests := []struct {
+		name      string
+		results   []error
+		wantState string
+	}{
+		{name: "échecs sous le seuil", results: []error{failure, failure}, wantState: "fermé"},
+		{name: "seuil atteint", results: []error{failure, failure, failure}, wantState: "ouvert"},
+		{name: "succès remet à zéro", results: []error{failure, failure, nil, failure, failure}, wantState: "fermé"},
+		{name: "404 remet à zéro", results: []error{failure, failure, ErrNotFound, failure, failure}, wantState: "fermé"},
+		{name: "annulation neutre", results: []error{failure, failure, canceled, failure}, wantState: "ouvert"},
+		{name: "limite locale neutre", results: []error{failure, failure, ErrThrottled, failure}, wantState: "ouvert"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Hour})
+			for _, err := range tt.results {
+				record(t, breaker, err)
+			}
+			if got := breaker.State(); got != tt.wantState {
+				t.Errorf("état = %s, attendu %s", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerCanceledProbe(t *testing.T) {
+	breaker := NewCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+	record(t, breaker, failure)
+	time.Sleep(2 * time.Millisecond)
+
+	// Appel de test annulé : le disjoncteur reste semi-ouvert et accepte un autre test
+	record(t, breaker, context.Canceled)
+	if got := breaker.State(); got != "semi-ouvert" {
+		t.Fatalf("état après un test annulé = %s, attendu semi-ouvert", got)
+	}
+
+	record(t, breaker, nil)
+	if got := breaker.State(); got != "fermé" {
+		t.Errorf("état après un test réussi = %s, attendu fermé", got)
+	}
+}
@@ -0,0 +1,482 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// CACHE TTL + LRU
+// ============================================================
+
+// CacheStats contient les compteurs d'un cache
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+}
+
+// TTLCache est un cache mémoire borné en taille (éviction LRU) dont les
+// entrées expirent après un TTL. Les chargements concurrents d'une même clé
+// sont regroupés : un seul appel au loader est effectué.
+// Les entrées expirées peuvent être conservées staleTTL de plus pour servir
+// de secours (GetStale) quand la source est en panne.
+type TTLCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	staleTTL   time.Duration
+	maxEntries int
+	order      *list.List // Élément en tête = utilisé le plus récemment
+	entries    map[string]*list.Element
+	stats      CacheStats
+	flights    flightGroup
+}
+
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	storedAt  time.Time
+	expiresAt time.Time
+}
+
+// NewTTLCache crée un cache avec le TTL et la taille maximale donnés.
+// maxEntries <= 0 signifie pas de limite de taille.
+func NewTTLCache(ttl time.Duration, maxEntries int) *TTLCache {
+	return &TTLCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get retourne la valeur associée à la clé si elle existe et n'a pas expiré
+func (c *TTLCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		if time.Since(entry.expiresAt) > c.staleTTL {
+			c.removeElement(elem)
+		}
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+	return entry.value, true
+}
+
+// GetStale retourne la valeur associée à la clé même expirée, tant qu'elle
+// est conservée, avec sa date d'enregistrement
+func (c *TTLCache) GetStale(key string) (interface{}, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Since(entry.expiresAt) > c.staleTTL {
+		c.removeElement(elem)
+		return nil, time.Time{}, false
+	}
+	return entry.value, entry.storedAt, true
+}
+
+// Set ajoute ou remplace une valeur, en évinçant la moins récente si besoin
+func (c *TTLCache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	expiresAt := now.Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value = value
+		entry.storedAt = now
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, storedAt: now, expiresAt: expiresAt})
+
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// GetOrLoad retourne la valeur en cache ou l'obtient via load.
+// Les appels concurrents pour une même clé partagent un seul appel à load,
+// qui ne dépend pas de l'annulation de l'appelant (voir flightGroup.do).
+// Les erreurs ne sont pas mises en cache.
+func (c *TTLCache) GetOrLoad(ctx context.Context, key string, load func(context.Context) (interface{}, error)) (interface{}, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	return c.flights.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.Set(key, value)
+		return value, nil
+	})
+}
+
+// Stats retourne une copie des compteurs du cache
+func (c *TTLCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+func (c *TTLCache) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// ============================================================
+// SOURCE AVEC CACHE
+// ============================================================
+
+// CacheConfig contient les TTL et tailles maximales par type de ressource.
+// Les valeurs nulles prennent leur valeur par défaut.
+type CacheConfig struct {
+	DigimonTTL         time.Duration // Digimons complets (défaut: 1h)
+	DigimonMaxEntries  int           // (défaut: 2000)
+	ListTTL            time.Duration // Pages de liste / recherches (défaut: 5min)
+	ListMaxEntries     int           // (défaut: 500)
+	ResourceTTL        time.Duration // Attributs, niveaux, types, champs, compétences (défaut: 6h)
+	ResourceMaxEntries int           // (défaut: 200)
+	StaleTTL           time.Duration // Conservation des entrées expirées, servies si l'API est en panne (défaut: 24h)
+}
+
+// CachedSource ajoute un cache mémoire devant une autre DigimonSource.
+// Les valeurs retournées sont partagées entre appelants : elles ne doivent
+// pas être modifiées.
+// Si la source est indisponible, la dernière valeur connue est servie même
+// expirée, et la requête est marquée comme périmée (voir StaleSince).
+type CachedSource struct {
+	next      DigimonSource
+	digimons  *TTLCache
+	lists     *TTLCache
+	resources *TTLCache
+}
+
+// NewCachedSource enveloppe la source donnée avec un cache
+func NewCachedSource(next DigimonSource, cfg CacheConfig) *CachedSource {
+	if cfg.DigimonTTL <= 0 {
+		cfg.DigimonTTL = time.Hour
+	}
+	if cfg.DigimonMaxEntries <= 0 {
+		cfg.DigimonMaxEntries = 2000
+	}
+	if cfg.ListTTL <= 0 {
+		cfg.ListTTL = 5 * time.Minute
+	}
+	if cfg.ListMaxEntries <= 0 {
+		cfg.ListMaxEntries = 500
+	}
+	if cfg.ResourceTTL <= 0 {
+		cfg.ResourceTTL = 6 * time.Hour
+	}
+	if cfg.ResourceMaxEntries <= 0 {
+		cfg.ResourceMaxEntries = 200
+	}
+	if cfg.StaleTTL <= 0 {
+		cfg.StaleTTL = 24 * time.Hour
+	}
+
+	source := &CachedSource{
+		next:      next,
+		digimons:  NewTTLCache(cfg.DigimonTTL, cfg.DigimonMaxEntries),
+		lists:     NewTTLCache(cfg.ListTTL, cfg.ListMaxEntries),
+		resources: NewTTLCache(cfg.ResourceTTL, cfg.ResourceMaxEntries),
+	}
+	for _, cache := range []*TTLCache{source.digimons, source.lists, source.resources} {
+		cache.staleTTL = cfg.StaleTTL
+	}
+	return source
+}
+
+// Stats retourne les compteurs de chaque cache, indexés par ressource
+func (s *CachedSource) Stats() map[string]CacheStats {
+	return map[string]CacheStats{
+		"digimons":  s.digimons.Stats(),
+		"lists":     s.lists.Stats(),
+		"resources": s.resources.Stats(),
+	}
+}
+
+// GetDigimonByID récupère un Digimon par son ID, depuis le cache si possible
+func (s *CachedSource) GetDigimonByID(ctx context.Context, id int) (*Digimon, int, error) {
+	value, statusCode, err := s.load(ctx, s.digimons, fmt.Sprintf("digimon/%d", id), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetDigimonByID(ctx, id)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Digimon), statusCode, nil
+}
+
+// GetDigimonByName récupère un Digimon par son nom, depuis le cache si possible
+func (s *CachedSource) GetDigimonByName(ctx context.Context, name string) (*Digimon, int, error) {
+	value, statusCode, err := s.load(ctx, s.digimons, "digimon/name/"+strings.ToLower(name), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetDigimonByName(ctx, name)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Digimon), statusCode, nil
+}
+
+// GetAllDigimons récupère une page de liste, depuis le cache si possible
+func (s *CachedSource) GetAllDigimons(ctx context.Context, opts *DigimonListOptions) (*DigimonListResponse, int, error) {
+	value, statusCode, err := s.load(ctx, s.lists, opts.cacheKey(), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetAllDigimons(ctx, opts)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*DigimonListResponse), statusCode, nil
+}
+
+// GetAttributeByID récupère un attribut par son ID, depuis le cache si possible
+func (s *CachedSource) GetAttributeByID(ctx context.Context, id int) (*Attribute, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, fmt.Sprintf("attribute/%d", id), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetAttributeByID(ctx, id)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Attribute), statusCode, nil
+}
+
+// GetAttributeByName récupère un attribut par son nom, depuis le cache si possible
+func (s *CachedSource) GetAttributeByName(ctx context.Context, name string) (*Attribute, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, "attribute/name/"+strings.ToLower(name), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetAttributeByName(ctx, name)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Attribute), statusCode, nil
+}
+
+// GetLevelByID récupère un niveau par son ID, depuis le cache si possible
+func (s *CachedSource) GetLevelByID(ctx context.Context, id int) (*Level, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, fmt.Sprintf("level/%d", id), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetLevelByID(ctx, id)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Level), statusCode, nil
+}
+
+// GetLevelByName récupère un niveau par son nom, depuis le cache si possible
+func (s *CachedSource) GetLevelByName(ctx context.Context, name string) (*Level, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, "level/name/"+strings.ToLower(name), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetLevelByName(ctx, name)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Level), statusCode, nil
+}
+
+// GetTypeByID récupère un type par son ID, depuis le cache si possible
+func (s *CachedSource) GetTypeByID(ctx context.Context, id int) (*Type, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, fmt.Sprintf("type/%d", id), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetTypeByID(ctx, id)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Type), statusCode, nil
+}
+
+// GetTypeByName récupère un type par son nom, depuis le cache si possible
+func (s *CachedSource) GetTypeByName(ctx context.Context, name string) (*Type, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, "type/name/"+strings.ToLower(name), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetTypeByName(ctx, name)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Type), statusCode, nil
+}
+
+// GetFieldByID récupère un champ par son ID, depuis le cache si possible
+func (s *CachedSource) GetFieldByID(ctx context.Context, id int) (*Field, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, fmt.Sprintf("field/%d", id), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetFieldByID(ctx, id)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Field), statusCode, nil
+}
+
+// GetFieldByName récupère un champ par son nom, depuis le cache si possible
+func (s *CachedSource) GetFieldByName(ctx context.Context, name string) (*Field, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, "field/name/"+strings.ToLower(name), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetFieldByName(ctx, name)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Field), statusCode, nil
+}
+
+// GetSkillByID récupère une compétence par son ID, depuis le cache si possible
+func (s *CachedSource) GetSkillByID(ctx context.Context, id int) (*Skill, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, fmt.Sprintf("skill/%d", id), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetSkillByID(ctx, id)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Skill), statusCode, nil
+}
+
+// GetSkillByName récupère une compétence par son nom, depuis le cache si possible
+func (s *CachedSource) GetSkillByName(ctx context.Context, name string) (*Skill, int, error) {
+	value, statusCode, err := s.load(ctx, s.resources, "skill/name/"+strings.ToLower(name), func(ctx context.Context) (interface{}, int, error) {
+		return s.next.GetSkillByName(ctx, name)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*Skill), statusCode, nil
+}
+
+// ListResources récupère une page de ressources, depuis le cache si possible
+func (s *CachedSource) ListResources(ctx context.Context, kind string, page, pageSize int) (*ResourceListResponse, int, error) {
+	key := fmt.Sprintf("%s?page=%d&pageSize=%d", kind, page, pageSize)
+	value, statusCode, err := s.load(ctx, s.resources, key, func(ctx context.Context) (interface{}, int, error) {
+		return s.next.ListResources(ctx, kind, page, pageSize)
+	})
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return value.(*ResourceListResponse), statusCode, nil
+}
+
+// HydrateDigimons complète les résumés d'une liste avec au plus
+// defaultHydrateConcurrency appels simultanés (voir HydrateFrom). Les
+// Digimons déjà en cache ne sont pas redemandés à l'API.
+func (s *CachedSource) HydrateDigimons(ctx context.Context, summaries []DigimonSummary) ([]HydratedDigimon, error) {
+	return HydrateFrom(ctx, s, summaries, defaultHydrateConcurrency)
+}
+
+// load interroge le cache puis, en cas d'absence, la source sous-jacente.
+// Le code HTTP d'échec est recalculé depuis l'erreur pour les appelants regroupés.
+// Si la source est indisponible, une valeur expirée est servie à la place.
+func (s *CachedSource) load(ctx context.Context, cache *TTLCache, key string, fetch func(context.Context) (interface{}, int, error)) (interface{}, int, error) {
+	value, err := cache.GetOrLoad(ctx, key, func(ctx context.Context) (interface{}, error) {
+		value, _, err := fetch(ctx)
+		return value, err
+	})
+	if err == nil {
+		return value, http.StatusOK, nil
+	}
+
+	if isRetryable(err) && ctx.Err() == nil {
+		if value, storedAt, ok := cache.GetStale(key); ok {
+			log.Printf("Cache - %s servi périmé (enregistré le %s): %v", key, storedAt.Format(time.DateTime), err)
+			markStale(ctx, storedAt)
+			return value, http.StatusOK, nil
+		}
+	}
+	return nil, upstreamStatus(err), err
+}
+
+// ============================================================
+// DÉ-DUPLICATION DES APPELS CONCURRENTS
+// ============================================================
+
+// flightTimeout borne la durée d'un chargement partagé, qui ne s'arrête pas
+// quand l'appelant qui l'a lancé abandonne
+const flightTimeout = 30 * time.Second
+
+// flightGroup regroupe les appels concurrents portant sur une même clé
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done  chan struct{} // Fermé quand value et err sont disponibles
+	value interface{}
+	err   error
+}
+
+// do exécute fn une seule fois par clé ; les autres appelants attendent le résultat.
+// fn tourne dans sa propre goroutine avec un contexte détaché de l'annulation
+// de l'appelant (mais pas de ses valeurs) et borné par flightTimeout : un
+// appelant pressé peut abandonner sans faire échouer les autres. Une panique
+// de fn est convertie en erreur pour ne jamais bloquer les appelants en attente.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(ctx, key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w : attente de %s abandonnée: %w", transportKind(ctx.Err()), key, ctx.Err())
+	}
+}
+
+// run exécute le chargement partagé d'une clé puis libère les appelants
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(context.Context) (interface{}, error)) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("Cache - panique pendant le chargement de %s: %v", key, recovered)
+			call.value, call.err = nil, fmt.Errorf("chargement de %s interrompu: %v", key, recovered)
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flightTimeout)
+	defer cancel()
+	call.value, call.err = fn(ctx)
+}
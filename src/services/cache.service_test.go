@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubSource est une DigimonSource simulée : seule GetDigimonByID est
+// implémentée (via fetch), les autres méthodes ne doivent pas être appelées
+type stubSource struct {
+	DigimonSource
+	calls atomic.Int32
+	fetch func(ctx context.Context, id int) (*Digimon, error)
+}
+
+func (s *stubSource) GetDigimonByID(ctx context.Context, id int) (*Digimon, int, error) {
+	s.calls.Add(1)
+	digimon, err := s.fetch(ctx, id)
+	if err != nil {
+		return nil, upstreamStatus(err), err
+	}
+	return digimon, 200, nil
+}
+
+// digimonNamed simule une réponse de l'API pour l'ID donné
+func digimonNamed(id int) *Digimon {
+	return &Digimon{ID: id, Name: fmt.Sprintf("Digimon %d", id)}
+}
+
+func TestTTLCacheExpiry(t *testing.T) {
+	cache := NewTTLCache(20*time.Millisecond, 0)
+	cache.Set("agumon", 1)
+
+	if value, ok := cache.Get("agumon"); !ok || value != 1 {
+		t.Fatalf("Get avant expiration = %v, %t, attendu 1, true", value, ok)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if value, ok := cache.Get("agumon"); ok {
+		t.Errorf("Get après expiration = %v, attendu absent", value)
+	}
+
+	// Sans conservation (staleTTL nul), l'entrée expirée est retirée
+	if _, _, ok := cache.GetStale("agumon"); ok {
+		t.Error("GetStale après expiration sans conservation = présent, attendu absent")
+	}
+}
+
+func TestTTLCacheLRUEviction(t *testing.T) {
+	tests := []struct {
+		name    string
+		touch   string // Clé lue avant l'ajout de la troisième (vide = aucune)
+		evicted string
+	}{
+		{name: "la plus ancienne est évincée", evicted: "a"},
+		{name: "une lecture protège de l'éviction", touch: "a", evicted: "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewTTLCache(time.Hour, 2)
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			if tt.touch != "" {
+				cache.Get(tt.touch)
+			}
+			cache.Set("c", 3)
+
+			for _, key := range []string{"a", "b", "c"} {
+				_, ok := cache.Get(key)
+				if want := key != tt.evicted; ok != want {
+					t.Errorf("Get(%q) présent = %t, attendu %t", key, ok, want)
+				}
+			}
+			if stats := cache.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+				t.Errorf("Stats() = %+v, attendu 1 éviction et 2 entrées", stats)
+			}
+		})
+	}
+}
+
+func TestTTLCacheStats(t *testing.T) {
+	cache := NewTTLCache(time.Hour, 0)
+	cache.Get("absent")
+	cache.Set("agumon", 1)
+	cache.Get("agumon")
+	cache.Get("agumon")
+	cache.Set("agumon", 2) // Remplacement : ni nouvelle entrée ni éviction
+
+	want := CacheStats{Hits: 2, Misses: 1, Evictions: 0, Entries: 1}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %+v, attendu %+v", got, want)
+	}
+}
+
+func TestCachedSourceSharedLoad(t *testing.T) {
+	release := make(chan struct{})
+	source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+		<-release
+		return digimonNamed(id), nil
+	}}
+	cached := NewCachedSource(source, CacheConfig{})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]*Digimon, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, errs[i] = cached.GetDigimonByID(context.Background(), 1)
+		}()
+	}
+
+	// Un appelant pressé abandonne sans faire échouer le chargement partagé
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := cached.GetDigimonByID(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("appelant abandonné: erreur = %v, attendu context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := source.calls.Load(); got != 1 {
+		t.Errorf("appels à la source = %d, attendu 1", got)
+	}
+	for i := range callers {
+		if errs[i] != nil || results[i] != results[0] {
+			t.Fatalf("appelant %d: %v, %v, attendu le Digimon partagé", i, results[i], errs[i])
+		}
+	}
+
+	// Le résultat est ensuite servi par le cache
+	if _, _, err := cached.GetDigimonByID(context.Background(), 1); err != nil || source.calls.Load() != 1 {
+		t.Errorf("second appel: erreur %v, %d appels à la source, attendu servi par le cache", err, source.calls.Load())
+	}
+}
+
+func TestCachedSourceErrorsNotCached(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+		if fail.Load() {
+			return nil, failure
+		}
+		return digimonNamed(id), nil
+	}}
+	cached := NewCachedSource(source, CacheConfig{})
+
+	if _, _, err := cached.GetDigimonByID(context.Background(), 1); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("premier appel: erreur = %v, attendu ErrUpstreamUnavailable", err)
+	}
+	fail.Store(false)
+	if _, _, err := cached.GetDigimonByID(context.Background(), 1); err != nil {
+		t.Fatalf("second appel: erreur = %v, attendu un nouvel appel réussi", err)
+	}
+	if got := source.calls.Load(); got != 2 {
+		t.Errorf("appels à la source = %d, attendu 2", got)
+	}
+}
+
+func TestCachedSourceStaleFallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantStale bool
+	}{
+		{name: "API en panne", err: failure, wantStale: true},
+		{name: "Digimon introuvable", err: &UpstreamError{Kind: ErrNotFound, StatusCode: 404}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fail atomic.Bool
+			source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+				if fail.Load() {
+					return nil, tt.err
+				}
+				return digimonNamed(id), nil
+			}}
+			cached := NewCachedSource(source, CacheConfig{DigimonTTL: 10 * time.Millisecond, StaleTTL: time.Hour})
+
+			if _, _, err := cached.GetDigimonByID(context.Background(), 1); err != nil {
+				t.Fatalf("premier appel: erreur = %v", err)
+			}
+			time.Sleep(20 * time.Millisecond)
+			fail.Store(true)
+
+			ctx := WithStaleTracker(context.Background())
+			digimon, _, err := cached.GetDigimonByID(ctx, 1)
+			_, stale := StaleSince(ctx)
+			if stale != tt.wantStale {
+				t.Errorf("requête marquée périmée = %t, attendu %t", stale, tt.wantStale)
+			}
+
+			if tt.wantStale {
+				if err != nil || digimon == nil || digimon.ID != 1 {
+					t.Errorf("GetDigimonByID = %v, %v, attendu la valeur expirée", digimon, err)
+				}
+			} else if !errors.Is(err, tt.err) {
+				t.Errorf("erreur = %v, attendu %v", err, tt.err)
+			}
+		})
+	}
+}
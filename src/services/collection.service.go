@@ -0,0 +1,434 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// CollectionVersion est la version du format d'export des collections.
+// Elle doit être incrémentée à chaque changement incompatible.
+const CollectionVersion = 1
+
+// collectionFileVersion est la version du fichier de stockage (2 : chaque
+// collection appartient à un utilisateur)
+const collectionFileVersion = 2
+
+// Collection des favoris, toujours présente et impossible à supprimer
+const (
+	FavoritesSlug = "favoris"
+	FavoritesName = "Favoris"
+)
+
+// Limites des collections
+const (
+	MaxCollectionNameLength = 60
+	MaxCollectionSize       = 1000
+)
+
+// ============================================================
+// COLLECTIONS
+// ============================================================
+
+// Collection est une liste nommée de Digimons, dans l'ordre d'ajout,
+// appartenant à un utilisateur
+type Collection struct {
+	Owner     int       `json:"owner"` // ID de l'utilisateur propriétaire
+	Slug      string    `json:"slug"`  // Identifiant dérivé du nom, unique par utilisateur (voir CollectionSlug)
+	Name      string    `json:"name"`
+	IDs       []int     `json:"ids"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Contains indique si le Digimon fait partie de la collection
+func (c Collection) Contains(id int) bool {
+	return slices.Contains(c.IDs, id)
+}
+
+// IsFavorites indique s'il s'agit de la collection des favoris
+func (c Collection) IsFavorites() bool {
+	return c.Slug == FavoritesSlug
+}
+
+// CollectionExport est le format d'export et d'import d'une collection
+type CollectionExport struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	IDs     []int  `json:"ids"`
+}
+
+// CollectionSlug dérive l'identifiant d'une collection de son nom :
+// "Mes Dragons préférés" -> "mes-dragons-preferes"
+func CollectionSlug(name string) string {
+	return strings.ReplaceAll(FoldName(name), " ", "-")
+}
+
+// collectionKey identifie une collection : chaque utilisateur a ses propres
+// identifiants
+type collectionKey struct {
+	owner int
+	slug  string
+}
+
+// collectionFile est le contenu du fichier de stockage
+type collectionFile struct {
+	Version     int          `json:"version"`
+	Collections []Collection `json:"collections"`
+}
+
+// ============================================================
+// STOCKAGE DES COLLECTIONS
+// ============================================================
+
+// CollectionStore conserve les collections des utilisateurs en mémoire et
+// dans un fichier JSON. Chaque modification réécrit le fichier de façon
+// atomique ; si l'écriture échoue, la modification est abandonnée.
+// Chaque utilisateur ne voit et ne modifie que ses collections ; ses favoris
+// existent toujours, même avant leur premier enregistrement.
+type CollectionStore struct {
+	path        string
+	mu          sync.RWMutex
+	collections map[collectionKey]Collection
+}
+
+// NewCollectionStore crée un stockage vide lié au fichier donné
+func NewCollectionStore(path string) *CollectionStore {
+	return &CollectionStore{
+		path:        path,
+		collections: map[collectionKey]Collection{},
+	}
+}
+
+// Path retourne le chemin du fichier de stockage
+func (s *CollectionStore) Path() string {
+	return s.path
+}
+
+// Load charge les collections depuis le disque. Un fichier absent n'est pas
+// une erreur : il sera créé à la première modification.
+func (s *CollectionStore) Load() error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erreur ouverture collections: %w", err)
+	}
+	defer file.Close()
+
+	var content collectionFile
+	if err := json.NewDecoder(file).Decode(&content); err != nil {
+		return fmt.Errorf("erreur décodage collections: %w", err)
+	}
+	if content.Version != collectionFileVersion {
+		return fmt.Errorf("version de collections %d non supportée (attendue: %d)",
+			content.Version, collectionFileVersion)
+	}
+
+	collections := make(map[collectionKey]Collection, len(content.Collections))
+	for _, collection := range content.Collections {
+		collections[collectionKey{collection.Owner, collection.Slug}] = collection
+	}
+
+	s.mu.Lock()
+	s.collections = collections
+	s.mu.Unlock()
+	return nil
+}
+
+// List retourne les collections d'un utilisateur : les favoris d'abord,
+// puis les autres par nom
+func (s *CollectionStore) List(owner int) []Collection {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := []Collection{favorites(s.collections, owner)}
+	for key, collection := range s.collections {
+		if key.owner == owner && key.slug != FavoritesSlug {
+			list = append(list, collection)
+		}
+	}
+	slices.SortFunc(list, func(a, b Collection) int {
+		switch {
+		case a.IsFavorites():
+			return -1
+		case b.IsFavorites():
+			return 1
+		}
+		return strings.Compare(FoldName(a.Name), FoldName(b.Name))
+	})
+	return list
+}
+
+// Get retourne la collection d'identifiant donné d'un utilisateur
+func (s *CollectionStore) Get(owner int, slug string) (*Collection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	collection, ok := lookup(s.collections, owner, slug)
+	if !ok {
+		return nil, fmt.Errorf("collection %q: %w", slug, ErrNotFound)
+	}
+	return &collection, nil
+}
+
+// IsFavorite indique si le Digimon fait partie des favoris de l'utilisateur
+func (s *CollectionStore) IsFavorite(owner, id int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return favorites(s.collections, owner).Contains(id)
+}
+
+// Create crée une collection vide pour un utilisateur
+func (s *CollectionStore) Create(owner int, name string) (*Collection, error) {
+	return s.update(owner, func(collections map[collectionKey]Collection) (string, error) {
+		name, slug, err := validCollectionName(name)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := lookup(collections, owner, slug); ok {
+			return "", fmt.Errorf("%w : la collection %q existe déjà", ErrConflict, name)
+		}
+
+		now := time.Now().UTC()
+		collections[collectionKey{owner, slug}] = Collection{
+			Owner: owner, Slug: slug, Name: name, IDs: []int{}, CreatedAt: now, UpdatedAt: now,
+		}
+		return slug, nil
+	})
+}
+
+// Delete supprime une collection d'un utilisateur (sauf ses favoris)
+func (s *CollectionStore) Delete(owner int, slug string) error {
+	_, err := s.update(owner, func(collections map[collectionKey]Collection) (string, error) {
+		if slug == FavoritesSlug {
+			return "", fmt.Errorf("%w : les favoris ne peuvent pas être supprimés", ErrInvalidInput)
+		}
+		if _, ok := collections[collectionKey{owner, slug}]; !ok {
+			return "", fmt.Errorf("collection %q: %w", slug, ErrNotFound)
+		}
+		delete(collections, collectionKey{owner, slug})
+		return "", nil
+	})
+	return err
+}
+
+// Add ajoute un Digimon à la collection (sans effet s'il y est déjà)
+func (s *CollectionStore) Add(owner int, slug string, id int) (*Collection, error) {
+	return s.modify(owner, slug, func(collection *Collection) error {
+		if collection.Contains(id) {
+			return nil
+		}
+		if len(collection.IDs) >= MaxCollectionSize {
+			return fmt.Errorf("%w : une collection compte au plus %d Digimons", ErrInvalidInput, MaxCollectionSize)
+		}
+		collection.IDs = append(collection.IDs, id)
+		return nil
+	})
+}
+
+// Remove retire un Digimon de la collection
+func (s *CollectionStore) Remove(owner int, slug string, id int) (*Collection, error) {
+	return s.modify(owner, slug, func(collection *Collection) error {
+		collection.IDs = slices.DeleteFunc(collection.IDs, func(member int) bool { return member == id })
+		return nil
+	})
+}
+
+// Export retourne la collection au format d'échange
+func (s *CollectionStore) Export(owner int, slug string) (*CollectionExport, error) {
+	collection, err := s.Get(owner, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &CollectionExport{Version: CollectionVersion, Name: collection.Name, IDs: collection.IDs}, nil
+}
+
+// Import ajoute les Digimons d'un export à la collection de même nom de
+// l'utilisateur, créée au besoin. Les Digimons déjà présents ne sont pas
+// dupliqués.
+func (s *CollectionStore) Import(owner int, export CollectionExport) (*Collection, error) {
+	if export.Version != CollectionVersion {
+		return nil, fmt.Errorf("%w : version d'export %d non supportée (attendue: %d)",
+			ErrInvalidInput, export.Version, CollectionVersion)
+	}
+
+	return s.update(owner, func(collections map[collectionKey]Collection) (string, error) {
+		name, slug, err := validCollectionName(export.Name)
+		if err != nil {
+			return "", err
+		}
+
+		now := time.Now().UTC()
+		collection, ok := lookup(collections, owner, slug)
+		if !ok {
+			collection = Collection{Owner: owner, Slug: slug, Name: name, IDs: []int{}, CreatedAt: now}
+		}
+		collection.IDs = slices.Clone(collection.IDs)
+
+		for _, id := range export.IDs {
+			if id <= 0 {
+				return "", fmt.Errorf("%w : ID invalide : %d", ErrInvalidInput, id)
+			}
+			if !collection.Contains(id) {
+				collection.IDs = append(collection.IDs, id)
+			}
+		}
+		if len(collection.IDs) > MaxCollectionSize {
+			return "", fmt.Errorf("%w : une collection compte au plus %d Digimons", ErrInvalidInput, MaxCollectionSize)
+		}
+
+		collection.UpdatedAt = now
+		collections[collectionKey{owner, slug}] = collection
+		return slug, nil
+	})
+}
+
+// modify applique une modification à une collection existante de l'utilisateur
+func (s *CollectionStore) modify(owner int, slug string, change func(collection *Collection) error) (*Collection, error) {
+	return s.update(owner, func(collections map[collectionKey]Collection) (string, error) {
+		collection, ok := lookup(collections, owner, slug)
+		if !ok {
+			return "", fmt.Errorf("collection %q: %w", slug, ErrNotFound)
+		}
+
+		collection.IDs = slices.Clone(collection.IDs)
+		if err := change(&collection); err != nil {
+			return "", err
+		}
+		collection.UpdatedAt = time.Now().UTC()
+		if collection.CreatedAt.IsZero() { // Favoris enregistrés pour la première fois
+			collection.CreatedAt = collection.UpdatedAt
+		}
+		collections[collectionKey{owner, slug}] = collection
+		return slug, nil
+	})
+}
+
+// update applique une modification à une copie des collections, l'écrit sur
+// disque puis la rend visible. change retourne l'identifiant de la
+// collection de l'utilisateur à renvoyer (vide si aucune).
+func (s *CollectionStore) update(owner int, change func(collections map[collectionKey]Collection) (string, error)) (*Collection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collections := maps.Clone(s.collections)
+	slug, err := change(collections)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCollections(s.path, collections); err != nil {
+		return nil, err
+	}
+	s.collections = collections
+
+	if slug == "" {
+		return nil, nil
+	}
+	collection := collections[collectionKey{owner, slug}]
+	return &collection, nil
+}
+
+// validCollectionName nettoie un nom de collection et en dérive l'identifiant
+func validCollectionName(name string) (string, string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	slug := CollectionSlug(name)
+
+	switch {
+	case slug == "":
+		return "", "", fmt.Errorf("%w : le nom de la collection est vide", ErrInvalidInput)
+	case utf8.RuneCountInString(name) > MaxCollectionNameLength:
+		return "", "", fmt.Errorf("%w : le nom de la collection dépasse %d caractères", ErrInvalidInput, MaxCollectionNameLength)
+	}
+	return name, slug, nil
+}
+
+// lookup retourne une collection de l'utilisateur. Les favoris existent
+// toujours, même avant leur premier enregistrement.
+func lookup(collections map[collectionKey]Collection, owner int, slug string) (Collection, bool) {
+	if slug == FavoritesSlug {
+		return favorites(collections, owner), true
+	}
+	collection, ok := collections[collectionKey{owner, slug}]
+	return collection, ok
+}
+
+// favorites retourne les favoris de l'utilisateur (vides s'ils n'ont jamais
+// été enregistrés)
+func favorites(collections map[collectionKey]Collection, owner int) Collection {
+	if collection, ok := collections[collectionKey{owner, FavoritesSlug}]; ok {
+		return collection
+	}
+	return Collection{Owner: owner, Slug: FavoritesSlug, Name: FavoritesName, IDs: []int{}}
+}
+
+// writeCollections écrit les collections sur disque de façon atomique
+// (fichier temporaire puis renommage), triées par utilisateur puis par
+// identifiant
+func writeCollections(path string, collections map[collectionKey]Collection) error {
+	content := collectionFile{Version: collectionFileVersion}
+	content.Collections = slices.SortedFunc(maps.Values(collections), func(a, b Collection) int {
+		if c := cmp.Compare(a.Owner, b.Owner); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Slug, b.Slug)
+	})
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("erreur création dossier collections: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".collections-*.json")
+	if err != nil {
+		return fmt.Errorf("erreur création fichier temporaire: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	encoder := json.NewEncoder(tmp)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erreur encodage collections: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erreur écriture collections: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("erreur renommage collections: %w", err)
+	}
+	return nil
+}
+
+// ============================================================
+// AFFICHAGE
+// ============================================================
+
+// CollectionCards récupère les Digimons donnés en parallèle et retourne
+// leurs cartes. Un Digimon indisponible garde une carte sans badges.
+func CollectionCards(ctx context.Context, source DigimonSource, ids []int) []DigimonCard {
+	summaries := make([]DigimonSummary, len(ids))
+	for i, id := range ids {
+		summaries[i] = DigimonSummary{ID: id, Name: fmt.Sprintf("Digimon n°%d", id)}
+	}
+
+	hydrated, _ := HydrateFrom(ctx, source, summaries, defaultHydrateConcurrency)
+	for i, result := range hydrated {
+		if result.Digimon != nil {
+			hydrated[i].Summary = result.Digimon.Summary("")
+		}
+	}
+	return Cards(hydrated)
+}
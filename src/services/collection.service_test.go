@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCollectionStoreOwners(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collections.json")
+	store := NewCollectionStore(path)
+	const alice, bob = 1, 2
+
+	if _, err := store.Create(alice, "Dragons"); err != nil {
+		t.Fatalf("Create(alice) = %v", err)
+	}
+	if _, err := store.Add(alice, "dragons", 6); err != nil {
+		t.Fatalf("Add(alice) = %v", err)
+	}
+	if _, err := store.Add(alice, FavoritesSlug, 1); err != nil {
+		t.Fatalf("Add(alice, favoris) = %v", err)
+	}
+
+	// Les collections d'un utilisateur sont invisibles pour les autres
+	if _, err := store.Get(bob, "dragons"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(bob, dragons) = %v, attendu ErrNotFound", err)
+	}
+	if _, err := store.Add(bob, "dragons", 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Add(bob, dragons) = %v, attendu ErrNotFound", err)
+	}
+	if err := store.Delete(bob, "dragons"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete(bob, dragons) = %v, attendu ErrNotFound", err)
+	}
+	if store.IsFavorite(bob, 1) {
+		t.Error("IsFavorite(bob, 1) = true, les favoris d'alice ne sont pas ceux de bob")
+	}
+
+	// Chacun peut utiliser le même nom
+	if _, err := store.Create(bob, "Dragons"); err != nil {
+		t.Errorf("Create(bob, Dragons) = %v, attendu sans erreur", err)
+	}
+
+	// Le fichier conserve le propriétaire de chaque collection
+	reloaded := NewCollectionStore(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	dragons, err := reloaded.Get(alice, "dragons")
+	if err != nil || !slices.Equal(dragons.IDs, []int{6}) {
+		t.Errorf("Get(alice, dragons) après rechargement = %v, %v, attendu [6]", dragons, err)
+	}
+	if !reloaded.IsFavorite(alice, 1) {
+		t.Error("IsFavorite(alice, 1) après rechargement = false")
+	}
+
+	slugs := func(list []Collection) []string {
+		result := []string{}
+		for _, collection := range list {
+			result = append(result, collection.Slug)
+		}
+		return result
+	}
+	if got := slugs(reloaded.List(bob)); !slices.Equal(got, []string{FavoritesSlug, "dragons"}) {
+		t.Errorf("List(bob) = %v, attendu [favoris dragons]", got)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Bornes du nombre de Digimons comparés en une fois
+const (
+	MinCompared = 2
+	MaxCompared = 6
+)
+
+// ============================================================
+// COMPARAISON DE DIGIMONS
+// ============================================================
+
+// ComparisonValue est une valeur d'une case de la matrice de comparaison
+type ComparisonValue struct {
+	Value  string `json:"value"`
+	Shared bool   `json:"shared"` // true si tous les Digimons comparés ont cette valeur
+}
+
+// ComparisonRow est une ligne de la matrice : une caractéristique (niveaux,
+// types...) avec les valeurs de chaque Digimon, dans l'ordre des colonnes
+type ComparisonRow struct {
+	Field  string              `json:"field"`
+	Label  string              `json:"label"`
+	Values [][]ComparisonValue `json:"values"`
+	Same   bool                `json:"same"` // true si tous les Digimons ont les mêmes valeurs
+}
+
+// Comparison est la matrice de comparaison de plusieurs Digimons
+type Comparison struct {
+	Digimons []DigimonSummary `json:"digimons"` // Colonnes, dans l'ordre demandé
+	Rows     []ComparisonRow  `json:"rows"`
+}
+
+// comparedLabels donne le libellé des caractéristiques comparées
+// (les facettes du filtrage avancé)
+var comparedLabels = map[string]string{
+	FacetLevels:     "Niveaux",
+	FacetAttributes: "Attributs",
+	FacetTypes:      "Types",
+	FacetFields:     "Champs",
+	FacetSkills:     "Compétences",
+	FacetXAntibody:  "X-Antibody",
+}
+
+// CompareDigimons récupère les Digimons demandés en parallèle puis construit
+// leur matrice de comparaison. Un Digimon introuvable fait échouer la
+// comparaison (erreur classée comme ErrNotFound).
+func CompareDigimons(ctx context.Context, source DigimonSource, ids []int) (*Comparison, error) {
+	if len(ids) < MinCompared || len(ids) > MaxCompared {
+		return nil, fmt.Errorf("%w : entre %d et %d Digimons peuvent être comparés", ErrInvalidInput, MinCompared, MaxCompared)
+	}
+
+	summaries := make([]DigimonSummary, len(ids))
+	for i, id := range ids {
+		summaries[i] = DigimonSummary{ID: id}
+	}
+
+	hydrated, _ := HydrateFrom(ctx, source, summaries, len(ids))
+
+	digimons := make([]*Digimon, len(hydrated))
+	for i, result := range hydrated {
+		if result.Err != nil {
+			return nil, fmt.Errorf("comparaison - Digimon %d: %w", result.Summary.ID, result.Err)
+		}
+		digimons[i] = result.Digimon
+	}
+
+	return NewComparison(digimons), nil
+}
+
+// NewComparison construit la matrice de comparaison des Digimons fournis.
+// Une valeur est partagée quand chacun des Digimons la possède.
+func NewComparison(digimons []*Digimon) *Comparison {
+	comparison := &Comparison{
+		Digimons: make([]DigimonSummary, len(digimons)),
+		Rows:     make([]ComparisonRow, 0, len(comparedLabels)),
+	}
+	for i, digimon := range digimons {
+		comparison.Digimons[i] = digimon.Summary("")
+	}
+
+	for _, compared := range (DigimonQuery{}).facets() {
+		row := ComparisonRow{
+			Field:  compared.name,
+			Label:  comparedLabels[compared.name],
+			Values: make([][]ComparisonValue, len(digimons)),
+			Same:   true,
+		}
+
+		columns := make([][]string, len(digimons))
+		for i, digimon := range digimons {
+			columns[i] = uniqueValues(compared.values(digimon))
+			slices.Sort(columns[i])
+		}
+
+		for i, column := range columns {
+			row.Values[i] = make([]ComparisonValue, len(column))
+			for j, value := range column {
+				shared := true
+				for _, other := range columns {
+					shared = shared && slices.Contains(other, value)
+				}
+				row.Values[i][j] = ComparisonValue{Value: value, Shared: shared}
+			}
+			row.Same = row.Same && slices.Equal(column, columns[0])
+		}
+
+		comparison.Rows = append(comparison.Rows, row)
+	}
+
+	return comparison
+}
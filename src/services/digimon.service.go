@@ -1,24 +1,5 @@
 package services
 
-import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"time"
-)
-
-// Configuration de l'API
-const (
-	digimonAPIBaseURL = "https://digi-api.com/api/v1"
-	defaultTimeout    = 10 * time.Second
-)
-
-// Client HTTP réutilisable
-var httpClient = &http.Client{
-	Timeout: defaultTimeout,
-}
-
 // ============================================================
 // STRUCTURES DE DONNÉES
 // ============================================================
@@ -26,7 +7,7 @@ var httpClient = &http.Client{
 // Image représente les différentes images d'un Digimon
 type Image struct {
 	Href        string `json:"href"`
-	Transparent string `json:"transparent,omitempty"`
+	Transparent bool   `json:"transparent,omitempty"`
 }
 
 // DigimonType représente un type de Digimon
@@ -75,6 +56,18 @@ type Digimon struct {
 	Fields      []DigimonField     `json:"fields"`
 	Skills      []DigimonSkill     `json:"skills"`
 	Descriptions []Description    `json:"descriptions,omitempty"`
+	PriorEvolutions []Evolution   `json:"priorEvolutions"`
+	NextEvolutions  []Evolution   `json:"nextEvolutions"`
+}
+
+// Evolution représente un lien d'évolution vers un autre Digimon.
+// ID vaut 0 quand le Digimon lié n'existe pas (encore) dans l'API.
+type Evolution struct {
+	ID        int    `json:"id"`
+	Digimon   string `json:"digimon"`
+	Condition string `json:"condition"`
+	Image     string `json:"image"`
+	URL       string `json:"url"`
 }
 
 // Description représente une description du Digimon
@@ -124,50 +117,9 @@ type Sort struct {
 }
 
 // ============================================================
-// FONCTIONS PRINCIPALES
+// OPTIONS DE LISTE
 // ============================================================
 
-// GetDigimonByID récupère un Digimon spécifique par son ID
-func GetDigimonByID(ctx context.Context, id int) (*Digimon, int, error) {
-	url := fmt.Sprintf("%s/digimon/%d", digimonAPIBaseURL, id)
-	return fetchDigimon(ctx, url)
-}
-
-// GetDigimonByName récupère un Digimon spécifique par son nom
-func GetDigimonByName(ctx context.Context, name string) (*Digimon, int, error) {
-	url := fmt.Sprintf("%s/digimon/%s", digimonAPIBaseURL, name)
-	return fetchDigimon(ctx, url)
-}
-
-// fetchDigimon est une fonction helper pour récupérer un Digimon
-func fetchDigimon(ctx context.Context, url string) (*Digimon, int, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur création requête: %w", err)
-	}
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur requête HTTP: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, resp.StatusCode,
-			fmt.Errorf("code HTTP inattendu: %d", resp.StatusCode)
-	}
-
-	var digimon Digimon
-	if err := json.NewDecoder(resp.Body).Decode(&digimon); err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur décodage JSON: %w", err)
-	}
-
-	return &digimon, resp.StatusCode, nil
-}
-
 // DigimonListOptions contient les options de filtrage pour la liste
 type DigimonListOptions struct {
 	Name       string // Recherche par nom similaire
@@ -177,75 +129,11 @@ type DigimonListOptions struct {
 	Level      string // Filtrer par niveau (Fresh, In-Training, Rookie, etc.)
 	Page       int    // Numéro de page (commence à 0)
 	PageSize   int    // Taille de la page (par défaut: 20)
-}
-
-// GetAllDigimons récupère la liste paginée des Digimons avec options de filtrage
-func GetAllDigimons(ctx context.Context, opts *DigimonListOptions) (*DigimonListResponse, int, error) {
-	url := fmt.Sprintf("%s/digimon", digimonAPIBaseURL)
-	
-	// Construction de l'URL avec les paramètres de requête
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur création requête: %w", err)
-	}
-
-	// Ajout des paramètres de requête si options fournies
-	if opts != nil {
-		q := req.URL.Query()
-		
-		if opts.Name != "" {
-			q.Add("name", opts.Name)
-		}
-		if opts.Exact {
-			q.Add("exact", "true")
-		}
-		if opts.Attribute != "" {
-			q.Add("attribute", opts.Attribute)
-		}
-		if opts.XAntibody != nil {
-			if *opts.XAntibody {
-				q.Add("xAntibody", "true")
-			} else {
-				q.Add("xAntibody", "false")
-			}
-		}
-		if opts.Level != "" {
-			q.Add("level", opts.Level)
-		}
-		if opts.Page > 0 {
-			q.Add("page", fmt.Sprintf("%d", opts.Page))
-		}
-		if opts.PageSize > 0 {
-			q.Add("pageSize", fmt.Sprintf("%d", opts.PageSize))
-		}
-		
-		req.URL.RawQuery = q.Encode()
-	}
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur requête HTTP: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, resp.StatusCode,
-			fmt.Errorf("code HTTP inattendu: %d", resp.StatusCode)
-	}
-
-	var listResponse DigimonListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&listResponse); err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur décodage JSON: %w", err)
-	}
-
-	return &listResponse, resp.StatusCode, nil
+	Sort       SortOrder // Tri global, appliqué par le catalogue local (l'API l'ignore)
 }
 
 // ============================================================
-// FONCTIONS POUR LES AUTRES RESSOURCES
+// AUTRES RESSOURCES
 // ============================================================
 
 // Attribute représente un attribut complet
@@ -255,46 +143,6 @@ type Attribute struct {
 	Digimons  []DigimonSummary `json:"digimons"`
 }
 
-// GetAttributeByID récupère un attribut par son ID
-func GetAttributeByID(ctx context.Context, id int) (*Attribute, int, error) {
-	url := fmt.Sprintf("%s/attribute/%d", digimonAPIBaseURL, id)
-	return fetchAttribute(ctx, url)
-}
-
-// GetAttributeByName récupère un attribut par son nom
-func GetAttributeByName(ctx context.Context, name string) (*Attribute, int, error) {
-	url := fmt.Sprintf("%s/attribute/%s", digimonAPIBaseURL, name)
-	return fetchAttribute(ctx, url)
-}
-
-func fetchAttribute(ctx context.Context, url string) (*Attribute, int, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur création requête: %w", err)
-	}
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur requête HTTP: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, resp.StatusCode,
-			fmt.Errorf("code HTTP inattendu: %d", resp.StatusCode)
-	}
-
-	var attribute Attribute
-	if err := json.NewDecoder(resp.Body).Decode(&attribute); err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur décodage JSON: %w", err)
-	}
-
-	return &attribute, resp.StatusCode, nil
-}
-
 // Level représente un niveau complet
 type Level struct {
 	ID       int              `json:"id"`
@@ -302,61 +150,24 @@ type Level struct {
 	Digimons []DigimonSummary `json:"digimons"`
 }
 
-// GetLevelByID récupère un niveau par son ID
-func GetLevelByID(ctx context.Context, id int) (*Level, int, error) {
-	url := fmt.Sprintf("%s/level/%d", digimonAPIBaseURL, id)
-	return fetchLevel(ctx, url)
-}
-
-// GetLevelByName récupère un niveau par son nom
-func GetLevelByName(ctx context.Context, name string) (*Level, int, error) {
-	url := fmt.Sprintf("%s/level/%s", digimonAPIBaseURL, name)
-	return fetchLevel(ctx, url)
-}
-
-func fetchLevel(ctx context.Context, url string) (*Level, int, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur création requête: %w", err)
-	}
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur requête HTTP: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, resp.StatusCode,
-			fmt.Errorf("code HTTP inattendu: %d", resp.StatusCode)
-	}
-
-	var level Level
-	if err := json.NewDecoder(resp.Body).Decode(&level); err != nil {
-		return nil, http.StatusInternalServerError,
-			fmt.Errorf("erreur décodage JSON: %w", err)
-	}
-
-	return &level, resp.StatusCode, nil
+// Type représente un type complet (Reptile, Dragon, etc.)
+type Type struct {
+	ID       int              `json:"id"`
+	Type     string           `json:"type"`
+	Digimons []DigimonSummary `json:"digimons"`
 }
 
-// ============================================================
-// VERSIONS SIMPLIFIÉES SANS CONTEXTE (pour compatibilité)
-// ============================================================
-
-// GetDigimonByIDSimple version simplifiée sans contexte
-func GetDigimonByIDSimple(id int) (*Digimon, int, error) {
-	return GetDigimonByID(context.Background(), id)
+// Field représente un champ/famille complet (Nature Spirits, Virus Busters, etc.)
+type Field struct {
+	ID       int              `json:"id"`
+	Field    string           `json:"field"`
+	Digimons []DigimonSummary `json:"digimons"`
 }
 
-// GetDigimonByNameSimple version simplifiée sans contexte
-func GetDigimonByNameSimple(name string) (*Digimon, int, error) {
-	return GetDigimonByName(context.Background(), name)
+// Skill représente une compétence complète et les Digimons qui la possèdent
+type Skill struct {
+	ID          int              `json:"id"`
+	Skill       string           `json:"skill"`
+	Description string           `json:"description,omitempty"`
+	Digimons    []DigimonSummary `json:"digimons"`
 }
-
-// GetAllDigimonsSimple version simplifiée sans contexte
-func GetAllDigimonsSimple(opts *DigimonListOptions) (*DigimonListResponse, int, error) {
-	return GetAllDigimons(context.Background(), opts)
-}
\ No newline at end of file
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ============================================================
+// ERREURS DU SERVICE
+// ============================================================
+
+// Catégories d'erreurs, utilisables avec errors.Is
+var (
+	ErrNotFound            = errors.New("ressource introuvable")
+	ErrUpstreamUnavailable = errors.New("source de données indisponible")
+	ErrTimeout             = errors.New("délai de réponse dépassé")
+	ErrDecode              = errors.New("réponse illisible")
+	ErrRateLimited         = errors.New("trop de requêtes")
+	ErrInvalidInput        = errors.New("paramètre invalide") // Données fournies par l'utilisateur
+	ErrConflict            = errors.New("ressource déjà existante")
+	ErrUnauthorized        = errors.New("authentification refusée")
+)
+
+// UpstreamError décrit l'échec d'un appel à l'API.
+// errors.Is(err, ErrNotFound) etc. fonctionne via Kind, et la cause d'origine
+// (erreur réseau, JSON...) reste accessible via errors.As.
+type UpstreamError struct {
+	Kind       error         // Catégorie (ErrNotFound, ErrTimeout...), nil si non classée
+	StatusCode int           // Code HTTP renvoyé par l'API (0 si aucune réponse)
+	Endpoint   string        // URL appelée
+	RetryAfter time.Duration // Délai demandé par l'API (en-tête Retry-After)
+	Err        error         // Cause d'origine
+}
+
+func (e *UpstreamError) Error() string {
+	message := "erreur API"
+	if e.Kind != nil {
+		message = e.Kind.Error()
+	}
+	if e.StatusCode != 0 {
+		message = fmt.Sprintf("%s (code HTTP %d)", message, e.StatusCode)
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *UpstreamError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// upstreamStatus retourne le code HTTP reçu de l'API pour un échec, ou 500
+// si aucune réponse n'a été obtenue : c'est le code des retours
+// (*T, int, error) des sources. La réponse faite au client, elle, dépend
+// uniquement de la catégorie de l'erreur (voir controllers.classifyError).
+func upstreamStatus(err error) int {
+	var upstream *UpstreamError
+	if errors.As(err, &upstream) && upstream.StatusCode != 0 {
+		return upstream.StatusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// statusKind classe un code HTTP d'échec renvoyé par l'API
+func statusKind(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode == http.StatusGatewayTimeout || statusCode == http.StatusRequestTimeout:
+		return ErrTimeout
+	case statusCode >= 500:
+		return ErrUpstreamUnavailable
+	}
+	return nil
+}
+
+// transportKind classe une erreur survenue avant d'obtenir une réponse
+func transportKind(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrTimeout
+	}
+	return ErrUpstreamUnavailable
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================
+// GRAPHE D'ÉVOLUTION
+// ============================================================
+
+// Directions d'un nœud par rapport à la racine du graphe
+const (
+	EvolutionRoot  = "root"
+	EvolutionNext  = "next"
+	EvolutionPrior = "prior"
+)
+
+// EvolutionOptions borne la résolution du graphe d'évolution
+type EvolutionOptions struct {
+	MaxDepth    int // Nombre maximal d'évolutions depuis la racine (défaut: 3)
+	MaxNodes    int // Nombre maximal de Digimons récupérés (défaut: 150)
+	Concurrency int // Digimons récupérés en parallèle (défaut: 8)
+}
+
+// EvolutionNode est un Digimon du graphe
+type EvolutionNode struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	Image     string         `json:"image"`
+	Levels    []DigimonLevel `json:"levels"`
+	Depth     int            `json:"depth"`     // Distance à la racine
+	Direction string         `json:"direction"` // root, next ou prior
+}
+
+// EvolutionEdge est une évolution de From vers To
+type EvolutionEdge struct {
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// EvolutionGraph contient les évolutions antérieures et suivantes d'un Digimon
+type EvolutionGraph struct {
+	RootID    int              `json:"rootId"`
+	MaxDepth  int              `json:"maxDepth"`
+	Truncated bool             `json:"truncated"` // MaxNodes atteint ou limite d'appels locale
+	Nodes     []*EvolutionNode `json:"nodes"`
+	Edges     []EvolutionEdge  `json:"edges"`
+
+	nodes map[int]*EvolutionNode
+	edges map[[2]int]int // (from, to) -> index dans Edges
+}
+
+// ResolveEvolutionGraph construit le graphe d'évolution autour d'un Digimon.
+// Les évolutions suivantes sont suivies vers l'avant et les antérieures vers
+// l'arrière, niveau par niveau, sans jamais revisiter un Digimon déjà vu
+// (les cycles, comme les dé-digivolutions, sont donc coupés).
+func ResolveEvolutionGraph(ctx context.Context, source DigimonSource, rootID int, opts EvolutionOptions) (*EvolutionGraph, error) {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 3
+	}
+	if opts.MaxNodes <= 0 {
+		opts.MaxNodes = 150
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+
+	root, _, err := source.GetDigimonByID(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &EvolutionGraph{
+		RootID:   root.ID,
+		MaxDepth: opts.MaxDepth,
+		nodes:    make(map[int]*EvolutionNode),
+		edges:    make(map[[2]int]int),
+	}
+	graph.addNode(root, 0, EvolutionRoot)
+
+	frontier := []*Digimon{root}
+	for depth := 1; depth <= opts.MaxDepth && len(frontier) > 0; depth++ {
+		// Collecte des voisins non encore visités
+		pending := []int{}
+		directions := make(map[int]string)
+		for _, digimon := range frontier {
+			direction := graph.nodes[digimon.ID].Direction
+
+			if direction != EvolutionPrior {
+				for _, evolution := range digimon.NextEvolutions {
+					if evolution.ID == 0 {
+						continue
+					}
+					graph.addEdge(digimon.ID, evolution.ID, evolution.Condition)
+					if _, seen := graph.nodes[evolution.ID]; !seen && directions[evolution.ID] == "" {
+						directions[evolution.ID] = EvolutionNext
+						pending = append(pending, evolution.ID)
+					}
+				}
+			}
+
+			if direction != EvolutionNext {
+				for _, evolution := range digimon.PriorEvolutions {
+					if evolution.ID == 0 {
+						continue
+					}
+					graph.addEdge(evolution.ID, digimon.ID, evolution.Condition)
+					if _, seen := graph.nodes[evolution.ID]; !seen && directions[evolution.ID] == "" {
+						directions[evolution.ID] = EvolutionPrior
+						pending = append(pending, evolution.ID)
+					}
+				}
+			}
+		}
+
+		// Limite du nombre de Digimons récupérés
+		if remaining := opts.MaxNodes - len(graph.nodes); len(pending) > remaining {
+			pending = pending[:max(remaining, 0)]
+			graph.Truncated = true
+		}
+
+		fetched, throttled, err := fetchDigimonsByID(ctx, source, pending, opts.Concurrency)
+		if err != nil {
+			return nil, err
+		}
+		if throttled {
+			graph.Truncated = true
+		}
+
+		frontier = frontier[:0]
+		for _, digimon := range fetched {
+			graph.addNode(digimon, depth, directions[digimon.ID])
+			frontier = append(frontier, digimon)
+		}
+	}
+
+	graph.finalize()
+	return graph, nil
+}
+
+// Node retourne le nœud d'ID donné (nil s'il n'est pas dans le graphe)
+func (g *EvolutionGraph) Node(id int) *EvolutionNode {
+	return g.nodes[id]
+}
+
+func (g *EvolutionGraph) addNode(digimon *Digimon, depth int, direction string) {
+	node := &EvolutionNode{
+		ID:        digimon.ID,
+		Name:      digimon.Name,
+		Image:     digimon.MainImage(),
+		Levels:    digimon.Levels,
+		Depth:     depth,
+		Direction: direction,
+	}
+	g.nodes[node.ID] = node
+	g.Nodes = append(g.Nodes, node)
+}
+
+func (g *EvolutionGraph) addEdge(from, to int, condition string) {
+	key := [2]int{from, to}
+	if i, ok := g.edges[key]; ok {
+		if g.Edges[i].Condition == "" {
+			g.Edges[i].Condition = condition
+		}
+		return
+	}
+	g.edges[key] = len(g.Edges)
+	g.Edges = append(g.Edges, EvolutionEdge{From: from, To: to, Condition: condition})
+}
+
+// finalize retire les arêtes vers des Digimons non récupérés
+// (limite de profondeur ou de taille atteinte)
+func (g *EvolutionGraph) finalize() {
+	edges := g.Edges[:0]
+	g.edges = make(map[[2]int]int)
+	for _, edge := range g.Edges {
+		if g.nodes[edge.From] != nil && g.nodes[edge.To] != nil {
+			g.edges[[2]int{edge.From, edge.To}] = len(edges)
+			edges = append(edges, edge)
+		}
+	}
+	g.Edges = edges
+
+	sort.SliceStable(g.Nodes, func(i, j int) bool { return g.Nodes[i].Depth < g.Nodes[j].Depth })
+}
+
+// ============================================================
+// ARBRE D'ÉVOLUTION (AFFICHAGE)
+// ============================================================
+
+// EvolutionTreeNode est un nœud de l'arbre d'évolution affiché.
+// Un Digimon atteignable par plusieurs branches n'est développé qu'une fois ;
+// ses autres occurrences sont marquées Repeated.
+type EvolutionTreeNode struct {
+	*EvolutionNode
+	Condition string               `json:"condition,omitempty"`
+	Repeated  bool                 `json:"repeated,omitempty"`
+	Children  []*EvolutionTreeNode `json:"children,omitempty"`
+}
+
+// Descendants retourne l'arbre des évolutions suivantes de la racine
+func (g *EvolutionGraph) Descendants() *EvolutionTreeNode {
+	return g.tree(func(edge EvolutionEdge, id int) (int, bool) { return edge.To, edge.From == id })
+}
+
+// Ancestors retourne l'arbre des évolutions antérieures de la racine
+func (g *EvolutionGraph) Ancestors() *EvolutionTreeNode {
+	return g.tree(func(edge EvolutionEdge, id int) (int, bool) { return edge.From, edge.To == id })
+}
+
+// tree parcourt le graphe en largeur depuis la racine dans une direction.
+// follow retourne le voisin atteint par l'arête si elle part du nœud id.
+func (g *EvolutionGraph) tree(follow func(edge EvolutionEdge, id int) (int, bool)) *EvolutionTreeNode {
+	root := &EvolutionTreeNode{EvolutionNode: g.nodes[g.RootID]}
+	expanded := map[int]bool{g.RootID: true}
+
+	queue := []*EvolutionTreeNode{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range g.Edges {
+			neighbour, ok := follow(edge, current.ID)
+			if !ok {
+				continue
+			}
+
+			child := &EvolutionTreeNode{
+				EvolutionNode: g.nodes[neighbour],
+				Condition:     edge.Condition,
+				Repeated:      expanded[neighbour],
+			}
+			current.Children = append(current.Children, child)
+
+			if !child.Repeated {
+				expanded[neighbour] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	return root
+}
+
+// ============================================================
+// UTILITAIRES
+// ============================================================
+
+// fetchDigimonsByID récupère plusieurs Digimons en parallèle (concurrence bornée).
+// Les Digimons introuvables (ErrNotFound) sont ignorés, de même que ceux que
+// le limiteur local n'a pas laissé partir à temps (ErrThrottled, signalé par
+// throttled) ; toute autre erreur est retournée.
+// L'ordre du résultat suit celui des IDs.
+func fetchDigimonsByID(ctx context.Context, source DigimonSource, ids []int, concurrency int) (digimons []*Digimon, throttled bool, err error) {
+	results := make([]*Digimon, len(ids))
+	semaphore := make(chan struct{}, max(concurrency, 1))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	var throttledCount atomic.Int32
+
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			digimon, _, err := source.GetDigimonByID(ctx, id)
+			if errors.Is(err, ErrNotFound) {
+				return
+			}
+			if errors.Is(err, ErrThrottled) {
+				throttledCount.Add(1)
+				return
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			results[i] = digimon
+		}(i, id)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, false, firstErr
+	}
+
+	digimons = make([]*Digimon, 0, len(results))
+	for _, digimon := range results {
+		if digimon != nil {
+			digimons = append(digimons, digimon)
+		}
+	}
+	return digimons, throttledCount.Load() > 0, nil
+}
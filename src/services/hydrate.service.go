@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultHydrateConcurrency borne le nombre de Digimons récupérés en parallèle
+const defaultHydrateConcurrency = 8
+
+// ============================================================
+// COMPLÉTION DES RÉSUMÉS
+// ============================================================
+
+// HydratedDigimon associe un résumé de liste au Digimon complet correspondant
+type HydratedDigimon struct {
+	Summary DigimonSummary
+	Digimon *Digimon // nil si la récupération a échoué
+	Err     error    // Cause de l'échec éventuel
+}
+
+// HydrationError signale les Digimons qui n'ont pas pu être complétés.
+// Les autres résultats restent utilisables.
+type HydrationError struct {
+	Total    int           // Nombre de résumés à compléter
+	Failures map[int]error // Erreur par ID de Digimon
+}
+
+func (e *HydrationError) Error() string {
+	for id, err := range e.Failures {
+		return fmt.Sprintf("%d/%d Digimons non complétés (ex: Digimon %d: %v)", len(e.Failures), e.Total, id, err)
+	}
+	return "aucun Digimon non complété"
+}
+
+func (e *HydrationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, err := range e.Failures {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Hydrator est une source capable de compléter des résumés de liste par
+// lot : CachedSource (appels parallèles bornés, via le cache) et
+// SnapshotSource (lectures en mémoire)
+type Hydrator interface {
+	HydrateDigimons(ctx context.Context, summaries []DigimonSummary) ([]HydratedDigimon, error)
+}
+
+// HydrateFrom récupère le Digimon complet de chaque résumé avec au plus
+// concurrency appels simultanés. Les résultats suivent l'ordre des résumés.
+// En cas d'échecs partiels, tous les résultats sont retournés accompagnés
+// d'une *HydrationError ; les appels passent par la source, et donc par son
+// cache éventuel.
+func HydrateFrom(ctx context.Context, source DigimonSource, summaries []DigimonSummary, concurrency int) ([]HydratedDigimon, error) {
+	if concurrency <= 0 {
+		concurrency = defaultHydrateConcurrency
+	}
+
+	results := make([]HydratedDigimon, len(summaries))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(concurrency, len(summaries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				digimon, _, err := source.GetDigimonByID(ctx, summaries[i].ID)
+				results[i] = HydratedDigimon{Summary: summaries[i], Digimon: digimon, Err: err}
+			}
+		}()
+	}
+
+	for i := range summaries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, hydrationFailures(results)
+}
+
+// hydrationFailures retourne une *HydrationError listant les résultats en
+// échec (nil si tous ont été complétés)
+func hydrationFailures(results []HydratedDigimon) error {
+	failures := map[int]error{}
+	for _, result := range results {
+		if result.Err != nil {
+			failures[result.Summary.ID] = result.Err
+		}
+	}
+	if len(failures) > 0 {
+		return &HydrationError{Total: len(results), Failures: failures}
+	}
+	return nil
+}
+
+// ============================================================
+// CARTES DE LISTE
+// ============================================================
+
+// DigimonCard est un résumé enrichi des badges affichés dans les listes
+// (niveaux, attributs, X-Antibody)
+type DigimonCard struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Href       string   `json:"href"`
+	Image      string   `json:"image"`
+	Levels     []string `json:"levels"`
+	Attributes []string `json:"attributes"`
+	XAntibody  bool     `json:"xAntibody"`
+	Hydrated   bool     `json:"hydrated"` // false = détails indisponibles, badges absents
+}
+
+// Card construit la carte de liste d'un résultat de complétion
+func (h HydratedDigimon) Card() DigimonCard {
+	card := DigimonCard{
+		ID:         h.Summary.ID,
+		Name:       h.Summary.Name,
+		Href:       h.Summary.Href,
+		Image:      h.Summary.Image,
+		Levels:     []string{},
+		Attributes: []string{},
+	}
+	if h.Digimon == nil {
+		return card
+	}
+
+	card.Hydrated = true
+	card.XAntibody = h.Digimon.XAntibody
+	for _, level := range h.Digimon.Levels {
+		card.Levels = append(card.Levels, level.Level)
+	}
+	for _, attribute := range h.Digimon.Attributes {
+		card.Attributes = append(card.Attributes, attribute.Attribute)
+	}
+	return card
+}
+
+// Cards convertit les résultats de complétion en cartes, dans le même ordre
+func Cards(hydrated []HydratedDigimon) []DigimonCard {
+	cards := make([]DigimonCard, len(hydrated))
+	for i, h := range hydrated {
+		cards[i] = h.Card()
+	}
+	return cards
+}
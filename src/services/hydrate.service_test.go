@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// summariesOf retourne les résumés des IDs donnés, dans l'ordre
+func summariesOf(ids ...int) []DigimonSummary {
+	summaries := make([]DigimonSummary, len(ids))
+	for i, id := range ids {
+		summaries[i] = DigimonSummary{ID: id}
+	}
+	return summaries
+}
+
+func TestHydrateFromOrder(t *testing.T) {
+	// Les premiers Digimons répondent le plus tard
+	source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+		time.Sleep(time.Duration(10-id) * time.Millisecond)
+		return digimonNamed(id), nil
+	}}
+
+	ids := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	results, err := HydrateFrom(context.Background(), source, summariesOf(ids...), 4)
+	if err != nil {
+		t.Fatalf("erreur inattendue: %v", err)
+	}
+	for i, result := range results {
+		if result.Summary.ID != ids[i] || result.Digimon == nil || result.Digimon.ID != ids[i] {
+			t.Errorf("résultat %d = %+v, attendu le Digimon %d", i, result, ids[i])
+		}
+	}
+}
+
+func TestHydrateFromPartialFailures(t *testing.T) {
+	missing := map[int]bool{2: true, 4: true}
+	source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+		if missing[id] {
+			return nil, &UpstreamError{Kind: ErrNotFound, StatusCode: 404}
+		}
+		return digimonNamed(id), nil
+	}}
+
+	results, err := HydrateFrom(context.Background(), source, summariesOf(1, 2, 3, 4, 5), 2)
+
+	var hydrationErr *HydrationError
+	if !errors.As(err, &hydrationErr) {
+		t.Fatalf("erreur = %v, attendu une *HydrationError", err)
+	}
+	if hydrationErr.Total != 5 || len(hydrationErr.Failures) != 2 || hydrationErr.Failures[2] == nil || hydrationErr.Failures[4] == nil {
+		t.Errorf("HydrationError = %+v, attendu les échecs de 2 et 4 sur 5", hydrationErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false, attendu la cause des échecs", err)
+	}
+
+	// Les autres résultats restent utilisables
+	for _, result := range results {
+		if got := result.Digimon != nil; got == missing[result.Summary.ID] {
+			t.Errorf("Digimon %d complété = %t, attendu %t", result.Summary.ID, got, !missing[result.Summary.ID])
+		}
+		if card := result.Card(); card.Hydrated == missing[result.Summary.ID] {
+			t.Errorf("carte %d: Hydrated = %t", result.Summary.ID, card.Hydrated)
+		}
+	}
+}
+
+func TestHydrateFromConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		summaries   int
+		wantMax     int32
+	}{
+		{name: "un seul appel à la fois", concurrency: 1, summaries: 10, wantMax: 1},
+		{name: "trois appels simultanés", concurrency: 3, summaries: 12, wantMax: 3},
+		{name: "moins de résumés que de places", concurrency: 8, summaries: 2, wantMax: 2},
+		{name: "valeur par défaut", concurrency: 0, summaries: 20, wantMax: defaultHydrateConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var active, peak atomic.Int32
+			source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+				current := active.Add(1)
+				defer active.Add(-1)
+				for {
+					previous := peak.Load()
+					if current <= previous || peak.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return digimonNamed(id), nil
+			}}
+
+			ids := make([]int, tt.summaries)
+			for i := range ids {
+				ids[i] = i + 1
+			}
+			if _, err := HydrateFrom(context.Background(), source, summariesOf(ids...), tt.concurrency); err != nil {
+				t.Fatalf("erreur inattendue: %v", err)
+			}
+			if got := peak.Load(); got != tt.wantMax {
+				t.Errorf("appels simultanés au maximum = %d, attendu %d", got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestCachedSourceHydrateDigimons(t *testing.T) {
+	source := &stubSource{fetch: func(ctx context.Context, id int) (*Digimon, error) {
+		return digimonNamed(id), nil
+	}}
+	cached := NewCachedSource(source, CacheConfig{})
+
+	if _, err := cached.HydrateDigimons(context.Background(), summariesOf(1, 2, 3)); err != nil {
+		t.Fatalf("premier lot: %v", err)
+	}
+	results, err := cached.HydrateDigimons(context.Background(), summariesOf(3, 2, 1, 4))
+	if err != nil {
+		t.Fatalf("second lot: %v", err)
+	}
+
+	// Seul le Digimon 4 manquait au cache
+	if got := source.calls.Load(); got != 4 {
+		t.Errorf("appels à la source = %d, attendu 4", got)
+	}
+	if results[0].Digimon.ID != 3 || results[3].Digimon.ID != 4 {
+		t.Errorf("ordre des résultats incorrect: %+v", results)
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// ============================================================
+// PARCOURS DE TOUTES LES PAGES
+// ============================================================
+
+// listPage est le résultat d'un appel à GetAllDigimons pour une page
+type listPage struct {
+	number int
+	data   *DigimonListResponse
+	err    error
+}
+
+// IterateDigimons parcourt tous les résumés correspondant aux options, page
+// après page, à partir de opts.Page (taille de page par défaut: 100).
+// Les options de l'appelant ne sont pas modifiées.
+//
+// Le parcours s'arrête dès que la boucle de l'appelant sort (break), que le
+// contexte est annulé, ou à la première erreur, qui est alors transmise
+// comme dernier élément avec un résumé vide :
+//
+//	for summary, err := range services.IterateDigimons(ctx, source, opts, true) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Avec prefetch, la page suivante est demandée pendant que l'appelant
+// traite la page courante.
+func IterateDigimons(ctx context.Context, source DigimonSource, opts *DigimonListOptions, prefetch bool) iter.Seq2[DigimonSummary, error] {
+	return func(yield func(DigimonSummary, error) bool) {
+		// La page suivante arrive par ce canal quand elle est préchargée. En
+		// sortie, un préchargement en cours est annulé puis attendu : aucune
+		// goroutine ne survit au parcours.
+		var next chan listPage
+		ctx, cancel := context.WithCancel(ctx)
+		defer func() {
+			cancel()
+			if next != nil {
+				<-next
+			}
+		}()
+
+		base := DigimonListOptions{}
+		if opts != nil {
+			base = *opts
+		}
+		if base.PageSize <= 0 {
+			base.PageSize = 100
+		}
+
+		fetch := func(number int) listPage {
+			pageOpts := base
+			pageOpts.Page = number
+			data, _, err := source.GetAllDigimons(ctx, &pageOpts)
+			if err != nil {
+				err = fmt.Errorf("erreur liste page %d: %w", number, err)
+			}
+			return listPage{number: number, data: data, err: err}
+		}
+
+		current := fetch(base.Page)
+
+		for {
+			if current.err == nil {
+				current.err = ctx.Err()
+			}
+			if current.err != nil {
+				yield(DigimonSummary{}, current.err)
+				return
+			}
+
+			data := current.data
+			last := data.Last || len(data.Content) == 0
+
+			if prefetch && !last {
+				next = make(chan listPage, 1)
+				go func(number int, out chan<- listPage) {
+					out <- fetch(number)
+				}(current.number+1, next)
+			}
+
+			for _, summary := range data.Content {
+				if !yield(summary, nil) {
+					return
+				}
+			}
+
+			if last {
+				return
+			}
+			if next != nil {
+				current = <-next
+				next = nil
+			} else {
+				current = fetch(current.number + 1)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedSource simule la liste paginée de l'API : total Digimons d'IDs 1 à
+// total. Seule GetAllDigimons est implémentée.
+type pagedSource struct {
+	DigimonSource
+	total    int
+	failPage int                                      // Page en erreur (0 = aucune)
+	block    func(ctx context.Context, page int) bool // true = la page attend l'annulation du contexte
+	calls    atomic.Int32
+	active   atomic.Int32 // Appels en cours
+	canceled atomic.Int32 // Appels terminés par l'annulation du contexte
+}
+
+func (s *pagedSource) GetAllDigimons(ctx context.Context, opts *DigimonListOptions) (*DigimonListResponse, int, error) {
+	s.calls.Add(1)
+	s.active.Add(1)
+	defer s.active.Add(-1)
+
+	if s.block != nil && s.block(ctx, opts.Page) {
+		<-ctx.Done()
+		s.canceled.Add(1)
+		return nil, 500, ctx.Err()
+	}
+	if opts.Page == s.failPage && s.failPage != 0 {
+		return nil, 503, failure
+	}
+
+	ids := make([]int, s.total)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	summaries := []DigimonSummary{}
+	for _, id := range Paginate(ids, opts.Page, opts.PageSize) {
+		summaries = append(summaries, DigimonSummary{ID: id})
+	}
+
+	totalPages := (s.total + opts.PageSize - 1) / opts.PageSize
+	return &DigimonListResponse{Content: summaries, Last: opts.Page >= totalPages-1}, 200, nil
+}
+
+// collectIDs parcourt toute la séquence et retourne les IDs et la dernière erreur
+func collectIDs(ctx context.Context, source DigimonSource, opts *DigimonListOptions, prefetch bool) ([]int, error) {
+	ids := []int{}
+	for summary, err := range IterateDigimons(ctx, source, opts, prefetch) {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, summary.ID)
+	}
+	return ids, nil
+}
+
+func TestIterateDigimonsPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		startPage int
+		prefetch  bool
+		wantFirst int
+		wantCount int
+		wantCalls int32
+	}{
+		{name: "trois pages", total: 25, wantFirst: 1, wantCount: 25, wantCalls: 3},
+		{name: "trois pages avec préchargement", total: 25, prefetch: true, wantFirst: 1, wantCount: 25, wantCalls: 3},
+		{name: "à partir de la deuxième page", total: 25, startPage: 1, prefetch: true, wantFirst: 11, wantCount: 15, wantCalls: 2},
+		{name: "liste vide", total: 0, prefetch: true, wantCount: 0, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &pagedSource{total: tt.total}
+			opts := &DigimonListOptions{Page: tt.startPage, PageSize: 10}
+
+			ids, err := collectIDs(context.Background(), source, opts, tt.prefetch)
+			if err != nil {
+				t.Fatalf("erreur inattendue: %v", err)
+			}
+			if len(ids) != tt.wantCount || (tt.wantCount > 0 && ids[0] != tt.wantFirst) || !slices.IsSorted(ids) {
+				t.Errorf("IDs = %v, attendu %d IDs croissants à partir de %d", ids, tt.wantCount, tt.wantFirst)
+			}
+			if got := source.calls.Load(); got != tt.wantCalls {
+				t.Errorf("appels à la source = %d, attendu %d", got, tt.wantCalls)
+			}
+			if opts.Page != tt.startPage {
+				t.Errorf("opts.Page modifié: %d, attendu %d", opts.Page, tt.startPage)
+			}
+		})
+	}
+}
+
+func TestIterateDigimonsError(t *testing.T) {
+	source := &pagedSource{total: 30, failPage: 1}
+
+	ids, err := collectIDs(context.Background(), source, &DigimonListOptions{PageSize: 10}, true)
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("erreur = %v, attendu ErrUpstreamUnavailable", err)
+	}
+	if len(ids) != 10 {
+		t.Errorf("%d IDs avant l'erreur, attendu la première page (10)", len(ids))
+	}
+}
+
+func TestIterateDigimonsEarlyStop(t *testing.T) {
+	// Le préchargement de la deuxième page ne se termine qu'à l'annulation
+	source := &pagedSource{total: 30, block: func(ctx context.Context, page int) bool { return page == 1 }}
+
+	for summary, err := range IterateDigimons(context.Background(), source, &DigimonListOptions{PageSize: 10}, true) {
+		if err != nil {
+			t.Fatalf("erreur inattendue: %v", err)
+		}
+		if summary.ID == 1 {
+			break
+		}
+	}
+
+	// Le préchargement a été annulé et attendu avant la fin du parcours
+	if got := source.active.Load(); got != 0 {
+		t.Errorf("%d appels encore en cours après la sortie de la boucle, attendu 0", got)
+	}
+	if got := source.canceled.Load(); got != 1 {
+		t.Errorf("préchargements annulés = %d, attendu 1", got)
+	}
+}
+
+func TestIterateDigimonsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := &pagedSource{total: 30}
+
+	ids := []int{}
+	var lastErr error
+	for summary, err := range IterateDigimons(ctx, source, &DigimonListOptions{PageSize: 10}, true) {
+		if err != nil {
+			lastErr = err
+			continue // L'erreur doit être le dernier élément
+		}
+		ids = append(ids, summary.ID)
+		if summary.ID == 10 {
+			cancel()
+		}
+	}
+
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("dernière erreur = %v, attendu context.Canceled", lastErr)
+	}
+	if len(ids) != 10 {
+		t.Errorf("%d IDs reçus, attendu l'arrêt après la première page (10)", len(ids))
+	}
+	if got := source.active.Load(); got != 0 {
+		t.Errorf("%d appels encore en cours, attendu 0", got)
+	}
+}
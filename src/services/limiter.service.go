@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrThrottled est retournée quand un appel n'a pas pu obtenir son tour
+// avant l'échéance de son contexte. Elle est classée comme ErrRateLimited.
+var ErrThrottled = fmt.Errorf("limite d'appels locale atteinte: %w", ErrRateLimited)
+
+// ============================================================
+// LIMITEUR DE DÉBIT (TOKEN BUCKET)
+// ============================================================
+
+// LimiterConfig paramètre le débit maximum d'appels vers l'API
+type LimiterConfig struct {
+	Rate  float64 // Appels par seconde en régime établi (défaut: 5)
+	Burst int     // Appels pouvant partir d'un coup après une période calme (défaut: 10)
+}
+
+// LimiterStats contient les compteurs du limiteur
+type LimiterStats struct {
+	Allowed     uint64 `json:"allowed"`     // Appels transmis (immédiatement ou après attente)
+	Throttled   uint64 `json:"throttled"`   // Appels ayant dû attendre leur tour
+	Rejected    uint64 `json:"rejected"`    // Appels abandonnés avant leur tour
+	Waiting     int    `json:"waiting"`     // Appels actuellement en attente
+	TotalWaitMs int64  `json:"totalWaitMs"` // Temps d'attente cumulé
+}
+
+// RateLimiter limite le débit d'appels selon un seau à jetons : chaque
+// appel consomme un jeton, les jetons se reconstituent au rythme Rate
+// jusqu'à Burst. Quand le seau est vide, chaque appel réserve le prochain
+// jeton disponible : les appels en attente passent dans leur ordre d'arrivée.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stats  LimiterStats
+}
+
+// NewRateLimiter crée un limiteur au seau plein
+func NewRateLimiter(cfg LimiterConfig) *RateLimiter {
+	if cfg.Rate <= 0 {
+		cfg.Rate = 5
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = 10
+	}
+
+	return &RateLimiter{
+		rate:   cfg.Rate,
+		burst:  float64(cfg.Burst),
+		tokens: float64(cfg.Burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait attend qu'un appel soit autorisé.
+// Si l'attente nécessaire dépasse l'échéance du contexte, ErrThrottled est
+// retournée immédiatement ; si le contexte est annulé pendant l'attente,
+// le jeton réservé est rendu.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		l.stats.Allowed++
+		l.mu.Unlock()
+		return nil
+	}
+
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		l.tokens++
+		l.stats.Rejected++
+		l.mu.Unlock()
+		return fmt.Errorf("attente de %s nécessaire: %w", wait.Round(time.Millisecond), ErrThrottled)
+	}
+	l.stats.Throttled++
+	l.stats.Waiting++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.stats.Waiting--
+		l.stats.Rejected++
+		l.mu.Unlock()
+		return fmt.Errorf("%w: %w", ErrThrottled, ctx.Err())
+	case <-timer.C:
+		l.mu.Lock()
+		l.stats.Waiting--
+		l.stats.Allowed++
+		l.stats.TotalWaitMs += wait.Milliseconds()
+		l.mu.Unlock()
+		return nil
+	}
+}
+
+// Stats retourne une copie des compteurs du limiteur
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	tests := []struct {
+		name  string
+		burst int
+		calls int
+	}{
+		{name: "sous la rafale", burst: 3, calls: 2},
+		{name: "rafale exacte", burst: 3, calls: 3},
+		{name: "au-delà de la rafale", burst: 3, calls: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Débit très lent : seul le seau initial permet de passer avant l'échéance
+			limiter := NewRateLimiter(LimiterConfig{Rate: 0.1, Burst: tt.burst})
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			allowed := 0
+			for range tt.calls {
+				err := limiter.Wait(ctx)
+				switch {
+				case err == nil:
+					allowed++
+				case !errors.Is(err, ErrThrottled) || !errors.Is(err, ErrRateLimited):
+					t.Fatalf("Wait() = %v, attendu ErrThrottled (classée ErrRateLimited)", err)
+				}
+			}
+
+			wantAllowed := min(tt.calls, tt.burst)
+			if allowed != wantAllowed {
+				t.Errorf("appels autorisés = %d, attendu %d", allowed, wantAllowed)
+			}
+			want := LimiterStats{Allowed: uint64(wantAllowed), Rejected: uint64(tt.calls - wantAllowed)}
+			if got := limiter.Stats(); got != want {
+				t.Errorf("Stats() = %+v, attendu %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		wantWait time.Duration // Attente d'un appel une fois le seau vide
+	}{
+		{name: "50 appels par seconde", rate: 50, wantWait: 20 * time.Millisecond},
+		{name: "10 appels par seconde", rate: 10, wantWait: 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter(LimiterConfig{Rate: tt.rate, Burst: 1})
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Fatalf("premier appel: %v", err)
+			}
+
+			start := time.Now()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Fatalf("second appel: %v", err)
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.wantWait*8/10 || elapsed > tt.wantWait*5 {
+				t.Errorf("attente = %s, attendu environ %s", elapsed, tt.wantWait)
+			}
+
+			// L'appel qui a attendu est compté comme ralenti
+			stats := limiter.Stats()
+			if stats.Allowed != 2 || stats.Throttled != 1 || stats.Rejected != 0 || stats.Waiting != 0 {
+				t.Errorf("Stats() = %+v, attendu 2 autorisés dont 1 ralenti", stats)
+			}
+			if stats.TotalWaitMs < tt.wantWait.Milliseconds()*8/10 {
+				t.Errorf("TotalWaitMs = %d, attendu environ %d", stats.TotalWaitMs, tt.wantWait.Milliseconds())
+			}
+		})
+	}
+}
+
+func TestRateLimiterCanceledWait(t *testing.T) {
+	limiter := NewRateLimiter(LimiterConfig{Rate: 10, Burst: 1})
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("premier appel: %v", err)
+	}
+
+	// Attente sans échéance, annulée avant son tour
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, ErrThrottled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() annulé = %v, attendu ErrThrottled et context.Canceled", err)
+	}
+	if stats := limiter.Stats(); stats.Rejected != 1 || stats.Waiting != 0 || stats.Allowed != 1 {
+		t.Errorf("Stats() = %+v, attendu 1 rejeté et plus aucune attente", stats)
+	}
+
+	// Le jeton réservé a été rendu : l'appel suivant n'attend pas un tour de plus
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("appel suivant: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("attente après annulation = %s, attendu moins de 100ms (jeton rendu)", elapsed)
+	}
+}
+
+func TestRateLimiterDeadlineTooShort(t *testing.T) {
+	limiter := NewRateLimiter(LimiterConfig{Rate: 1, Burst: 1})
+	limiter.Wait(context.Background())
+
+	// L'attente nécessaire (1s) dépasse l'échéance : refus immédiat, sans attendre
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.Wait(ctx); !errors.Is(err, ErrThrottled) {
+		t.Fatalf("Wait() = %v, attendu ErrThrottled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("refus après %s, attendu immédiat", elapsed)
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Types de ressources listables côté API (/level, /attribute, /type, /field)
+const (
+	ResourceLevel     = "level"
+	ResourceAttribute = "attribute"
+	ResourceType      = "type"
+	ResourceField     = "field"
+)
+
+// ============================================================
+// LISTES DE RESSOURCES
+// ============================================================
+
+// ResourceListResponse représente la réponse paginée d'un endpoint de liste
+// de ressources (ex: GET /level)
+type ResourceListResponse struct {
+	Content  ResourceListContent `json:"content"`
+	Pageable ResourcePageable    `json:"pageable"`
+}
+
+// ResourceListContent contient les éléments d'une page de ressources
+type ResourceListContent struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Fields      []ResourceSummary `json:"fields"`
+}
+
+// ResourceSummary représente une ressource dans une liste
+type ResourceSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+// ResourcePageable contient la pagination d'une liste de ressources
+type ResourcePageable struct {
+	CurrentPage    int    `json:"currentPage"`
+	ElementsOnPage int    `json:"elementsOnPage"`
+	TotalElements  int    `json:"totalElements"`
+	TotalPages     int    `json:"totalPages"`
+	PreviousPage   string `json:"previousPage"`
+	NextPage       string `json:"nextPage"`
+}
+
+// ListAllResourceNames parcourt toutes les pages d'un type de ressources
+// et retourne les noms dans l'ordre des IDs (ex: Fresh, In-Training, Rookie...)
+func ListAllResourceNames(ctx context.Context, source DigimonSource, kind string) ([]string, error) {
+	resources := []ResourceSummary{}
+	for page := 0; ; page++ {
+		data, _, err := source.ListResources(ctx, kind, page, 100)
+		if err != nil {
+			return nil, fmt.Errorf("erreur liste %s page %d: %w", kind, page, err)
+		}
+
+		resources = append(resources, data.Content.Fields...)
+		if page >= data.Pageable.TotalPages-1 || len(data.Content.Fields) == 0 {
+			break
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].ID < resources[j].ID
+	})
+
+	names := make([]string, len(resources))
+	for i, resource := range resources {
+		names[i] = resource.Name
+	}
+	return names, nil
+}
+
+// ============================================================
+// OPTIONS DES FILTRES
+// ============================================================
+
+// FilterOptions contient les valeurs proposées dans les formulaires de filtre
+type FilterOptions struct {
+	Levels     []string  `json:"levels"`
+	Attributes []string  `json:"attributes"`
+	Types      []string  `json:"types"`
+	Fields     []string  `json:"fields"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Fallback   bool      `json:"fallback"` // true = listes par défaut, API indisponible
+}
+
+// Values retourne les valeurs d'un type de ressource
+func (o *FilterOptions) Values(kind string) []string {
+	switch kind {
+	case ResourceLevel:
+		return o.Levels
+	case ResourceAttribute:
+		return o.Attributes
+	case ResourceType:
+		return o.Types
+	case ResourceField:
+		return o.Fields
+	}
+	return nil
+}
+
+// OptionsConfig paramètre le fournisseur d'options
+type OptionsConfig struct {
+	Refresh  time.Duration // Durée de validité des listes (défaut: 1h)
+	Fallback FilterOptions // Listes utilisées tant que l'API n'a pas répondu
+}
+
+// OptionsProvider charge les listes de niveaux, attributs, types et champs
+// depuis l'API et les garde en mémoire. Une fois expirées, les listes sont
+// rafraîchies en arrière-plan : les appelants reçoivent toujours la dernière
+// version connue sans attendre.
+type OptionsProvider struct {
+	source  DigimonSource
+	refresh time.Duration
+
+	mu          sync.Mutex
+	options     *FilterOptions
+	nextRefresh time.Time
+	refreshing  bool
+}
+
+// NewOptionsProvider crée un fournisseur d'options pour la source donnée
+func NewOptionsProvider(source DigimonSource, cfg OptionsConfig) *OptionsProvider {
+	if cfg.Refresh <= 0 {
+		cfg.Refresh = time.Hour
+	}
+
+	fallback := cfg.Fallback
+	fallback.Fallback = true
+
+	return &OptionsProvider{
+		source:  source,
+		refresh: cfg.Refresh,
+		options: &fallback,
+	}
+}
+
+// Get retourne les options courantes.
+// Tant que seules les listes par défaut sont connues, le chargement est fait
+// immédiatement ; ensuite les listes expirées sont rechargées en arrière-plan.
+func (p *OptionsProvider) Get(ctx context.Context) *FilterOptions {
+	p.mu.Lock()
+	options := p.options
+	if p.refreshing || time.Now().Before(p.nextRefresh) {
+		p.mu.Unlock()
+		return options
+	}
+	p.refreshing = true
+	p.mu.Unlock()
+
+	if options.Fallback {
+		return p.Refresh(ctx)
+	}
+
+	go p.Refresh(context.Background())
+	return options
+}
+
+// Refresh recharge toutes les listes depuis l'API.
+// En cas d'échec, les listes précédentes sont conservées et un nouvel
+// essai est programmé une minute plus tard.
+func (p *OptionsProvider) Refresh(ctx context.Context) *FilterOptions {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	options := &FilterOptions{UpdatedAt: time.Now()}
+	var err error
+	for _, target := range []struct {
+		kind   string
+		values *[]string
+	}{
+		{ResourceLevel, &options.Levels},
+		{ResourceAttribute, &options.Attributes},
+		{ResourceType, &options.Types},
+		{ResourceField, &options.Fields},
+	} {
+		if *target.values, err = ListAllResourceNames(ctx, p.source, target.kind); err != nil {
+			break
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refreshing = false
+
+	if err != nil {
+		log.Printf("Options - rafraîchissement impossible: %s", err.Error())
+		p.nextRefresh = time.Now().Add(min(p.refresh, time.Minute))
+		return p.options
+	}
+
+	p.options = options
+	p.nextRefresh = time.Now().Add(p.refresh)
+	return options
+}
@@ -0,0 +1,26 @@
+package services
+
+// ============================================================
+// PAGINATION LOCALE
+// ============================================================
+
+// Paginate retourne les éléments de la page demandée (à partir de 0).
+// Sert à découper localement les listes que l'API renvoie en un bloc
+// (Digimons d'un niveau, d'un attribut...). Une page hors limites est vide.
+func Paginate[T any](items []T, page, pageSize int) []T {
+	if page < 0 || pageSize <= 0 {
+		return items[:0]
+	}
+
+	start := pageOffset(len(items), page, pageSize)
+	end := min(start+pageSize, len(items))
+	return items[start:end]
+}
+
+// pageOffset retourne l'indice du premier élément de la page, borné à la
+// longueur de la liste. La page est ramenée à la première page vide avant
+// la multiplication : un ?page= énorme ne peut pas déborder en négatif.
+func pageOffset(length, page, pageSize int) int {
+	page = min(page, length/pageSize+1)
+	return min(page*pageSize, length)
+}
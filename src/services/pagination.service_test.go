@@ -0,0 +1,33 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		page     int
+		pageSize int
+		want     []int
+	}{
+		{name: "première page", page: 0, pageSize: 2, want: []int{1, 2}},
+		{name: "dernière page incomplète", page: 2, pageSize: 2, want: []int{5}},
+		{name: "page hors limites", page: 3, pageSize: 2, want: []int{}},
+		{name: "page énorme sans débordement", page: math.MaxInt / 2, pageSize: 4, want: []int{}},
+		{name: "page négative", page: -1, pageSize: 2, want: []int{}},
+		{name: "taille nulle", page: 0, pageSize: 0, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Paginate(items, tt.page, tt.pageSize); !slices.Equal(got, tt.want) {
+				t.Errorf("Paginate(page=%d, pageSize=%d) = %v, attendu %v", tt.page, tt.pageSize, got, tt.want)
+			}
+		})
+	}
+}
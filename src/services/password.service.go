@@ -0,0 +1,76 @@
+package services
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Paramètres de hachage des mots de passe (PBKDF2-HMAC-SHA256).
+// Le nombre d'itérations est enregistré dans chaque empreinte : il peut être
+// augmenté sans invalider les mots de passe existants.
+const (
+	passwordScheme     = "pbkdf2-sha256"
+	passwordIterations = 600_000
+	passwordSaltSize   = 16
+	passwordKeySize    = 32
+)
+
+// ============================================================
+// MOTS DE PASSE
+// ============================================================
+
+// HashPassword calcule l'empreinte salée d'un mot de passe, au format
+// "pbkdf2-sha256$<itérations>$<sel>$<clé>" (sel et clé en base64)
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("erreur génération du sel: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, passwordKeySize)
+	if err != nil {
+		return "", fmt.Errorf("erreur hachage mot de passe: %w", err)
+	}
+
+	return strings.Join([]string{
+		passwordScheme,
+		strconv.Itoa(passwordIterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// VerifyPassword indique si le mot de passe correspond à l'empreinte.
+// La comparaison se fait en temps constant ; une empreinte illisible ne
+// correspond à aucun mot de passe.
+func VerifyPassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordScheme {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(expected))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, expected) == 1
+}
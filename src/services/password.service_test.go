@@ -0,0 +1,65 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordRoundTrip(t *testing.T) {
+	hash, err := HashPassword("agumon-42")
+	if err != nil {
+		t.Fatalf("erreur inattendue: %v", err)
+	}
+	if !strings.HasPrefix(hash, passwordScheme+"$") {
+		t.Errorf("empreinte = %q, attendu le préfixe %q", hash, passwordScheme+"$")
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{name: "bon mot de passe", password: "agumon-42", want: true},
+		{name: "mauvais mot de passe", password: "agumon-43", want: false},
+		{name: "casse différente", password: "Agumon-42", want: false},
+		{name: "mot de passe vide", password: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPassword(hash, tt.password); got != tt.want {
+				t.Errorf("VerifyPassword(%q) = %t, attendu %t", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordSalt(t *testing.T) {
+	first, _ := HashPassword("gabumon-42")
+	second, _ := HashPassword("gabumon-42")
+	if first == second {
+		t.Error("deux empreintes du même mot de passe sont identiques, attendu des sels différents")
+	}
+}
+
+func TestVerifyPasswordMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "empreinte vide", hash: ""},
+		{name: "mot de passe en clair", hash: "agumon-42"},
+		{name: "schéma inconnu", hash: "md5$1$c2Vs$a2V5"},
+		{name: "itérations invalides", hash: passwordScheme + "$0$c2Vs$a2V5"},
+		{name: "sel illisible", hash: passwordScheme + "$1$!!!$a2V5"},
+		{name: "clé vide", hash: passwordScheme + "$1$c2Vs$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if VerifyPassword(tt.hash, "agumon-42") {
+				t.Errorf("VerifyPassword(%q) = true, attendu false", tt.hash)
+			}
+		})
+	}
+}
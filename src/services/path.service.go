@@ -0,0 +1,231 @@
+package services
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ============================================================
+// CHEMIN D'ÉVOLUTION LE PLUS COURT
+// ============================================================
+
+// PathOptions borne et paramètre la recherche de chemin
+type PathOptions struct {
+	WeightConditions bool // Une évolution conditionnelle coûte 2 au lieu de 1
+	MaxNodes         int  // Nombre maximal de Digimons explorés (défaut: 300)
+	MaxPaths         int  // Nombre maximal de chemins retournés (défaut: 5)
+}
+
+// EvolutionStep est une étape d'un chemin d'évolution
+type EvolutionStep struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	Image     string         `json:"image"`
+	Levels    []DigimonLevel `json:"levels"`
+	Condition string         `json:"condition,omitempty"` // Condition pour atteindre cette étape
+}
+
+// EvolutionPath est une suite d'évolutions de la source vers la cible
+type EvolutionPath struct {
+	Steps []EvolutionStep `json:"steps"`
+	Cost  int             `json:"cost"`
+}
+
+// PathResult contient le meilleur chemin et les alternatives de même coût
+type PathResult struct {
+	From     EvolutionStep   `json:"from"`
+	To       EvolutionStep   `json:"to"`
+	Found    bool            `json:"found"`
+	Paths    []EvolutionPath `json:"paths"`    // Le premier est le chemin principal
+	Explored int             `json:"explored"` // Nombre de Digimons explorés
+	Limited  bool            `json:"limited"`  // MaxNodes atteint avant la fin
+}
+
+// FindEvolutionPaths cherche les plus courts chemins d'évolution (Dijkstra)
+// de fromID vers toID en suivant les évolutions suivantes. Les Digimons sont
+// récupérés au fur et à mesure depuis la source ; avec une SnapshotSource,
+// la recherche se fait entièrement en local.
+func FindEvolutionPaths(ctx context.Context, source DigimonSource, fromID, toID int, opts PathOptions) (*PathResult, error) {
+	if opts.MaxNodes <= 0 {
+		opts.MaxNodes = 300
+	}
+	if opts.MaxPaths <= 0 {
+		opts.MaxPaths = 5
+	}
+
+	from, _, err := source.GetDigimonByID(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, _, err := source.GetDigimonByID(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PathResult{
+		From:  stepOf(from, ""),
+		To:    stepOf(to, ""),
+		Paths: []EvolutionPath{},
+	}
+
+	digimons := map[int]*Digimon{from.ID: from, to.ID: to}
+	dist := map[int]int{from.ID: 0}
+	preds := map[int][]pathEdge{}
+	done := map[int]bool{}
+
+	queue := &pathQueue{{id: from.ID, cost: 0}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(pathItem)
+		if done[item.id] || item.cost > dist[item.id] {
+			continue
+		}
+		// Toutes les alternatives de même coût sont connues : on s'arrête
+		if d, ok := dist[to.ID]; ok && item.cost > d {
+			break
+		}
+		done[item.id] = true
+		if item.id == to.ID {
+			continue
+		}
+
+		if len(done) > opts.MaxNodes {
+			result.Limited = true
+			break
+		}
+
+		current, ok := digimons[item.id]
+		if !ok {
+			current, _, err = source.GetDigimonByID(ctx, item.id)
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			digimons[item.id] = current
+		}
+		result.Explored++
+
+		for _, evolution := range current.NextEvolutions {
+			if evolution.ID == 0 || done[evolution.ID] {
+				continue
+			}
+
+			cost := item.cost + 1
+			if opts.WeightConditions && evolution.Condition != "" {
+				cost++
+			}
+
+			edge := pathEdge{from: item.id, condition: evolution.Condition}
+			known, seen := dist[evolution.ID]
+			switch {
+			case !seen || cost < known:
+				dist[evolution.ID] = cost
+				preds[evolution.ID] = []pathEdge{edge}
+				heap.Push(queue, pathItem{id: evolution.ID, cost: cost})
+			case cost == known:
+				preds[evolution.ID] = append(preds[evolution.ID], edge)
+			}
+		}
+	}
+
+	if _, ok := dist[to.ID]; !ok || from.ID == to.ID {
+		result.Found = from.ID == to.ID
+		if result.Found {
+			result.Paths = append(result.Paths, EvolutionPath{Steps: []EvolutionStep{result.From}})
+		}
+		return result, nil
+	}
+	result.Found = true
+
+	// Reconstruction des chemins en remontant les prédécesseurs depuis la cible
+	ids := enumeratePaths(preds, from.ID, to.ID, opts.MaxPaths)
+	for _, path := range ids {
+		steps := make([]EvolutionStep, 0, len(path))
+		for i, hop := range path {
+			digimon, ok := digimons[hop.id]
+			if !ok {
+				digimon, _, err = source.GetDigimonByID(ctx, hop.id)
+				if err != nil {
+					return nil, fmt.Errorf("erreur étape %d: %w", i, err)
+				}
+				digimons[hop.id] = digimon
+			}
+			steps = append(steps, stepOf(digimon, hop.condition))
+		}
+		result.Paths = append(result.Paths, EvolutionPath{Steps: steps, Cost: dist[to.ID]})
+	}
+
+	return result, nil
+}
+
+// pathEdge est un prédécesseur sur un plus court chemin
+type pathEdge struct {
+	from      int
+	condition string
+}
+
+// pathHop est une étape (Digimon et condition pour l'atteindre)
+type pathHop struct {
+	id        int
+	condition string
+}
+
+// enumeratePaths liste jusqu'à limit chemins de from vers to
+func enumeratePaths(preds map[int][]pathEdge, from, to, limit int) [][]pathHop {
+	paths := [][]pathHop{}
+
+	var walk func(id int, suffix []pathHop)
+	walk = func(id int, suffix []pathHop) {
+		if len(paths) >= limit {
+			return
+		}
+		if id == from {
+			path := append([]pathHop{{id: from}}, suffix...)
+			paths = append(paths, path)
+			return
+		}
+		for _, edge := range preds[id] {
+			hop := pathHop{id: id, condition: edge.condition}
+			walk(edge.from, append([]pathHop{hop}, suffix...))
+		}
+	}
+	walk(to, nil)
+
+	return paths
+}
+
+func stepOf(digimon *Digimon, condition string) EvolutionStep {
+	return EvolutionStep{
+		ID:        digimon.ID,
+		Name:      digimon.Name,
+		Image:     digimon.MainImage(),
+		Levels:    digimon.Levels,
+		Condition: condition,
+	}
+}
+
+// ============================================================
+// FILE DE PRIORITÉ
+// ============================================================
+
+type pathItem struct {
+	id   int
+	cost int
+}
+
+// pathQueue est un tas binaire de pathItem ordonné par coût croissant
+type pathQueue []pathItem
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathItem)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
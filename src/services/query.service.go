@@ -0,0 +1,224 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// MOTEUR DE REQUÊTE LOCAL
+// ============================================================
+
+// DigimonQuery décrit un filtrage local sur des Digimons complets.
+// Les valeurs d'une même facette sont combinées en OU, les facettes
+// entre elles en ET. Une facette vide ne filtre rien.
+type DigimonQuery struct {
+	Levels     []string
+	Attributes []string
+	Types      []string
+	Fields     []string
+	Skills     []string
+	XAntibody  *bool     // nil = pas de filtre
+	Sort       SortOrder // Ordre des résultats (zéro = ordre du catalogue)
+}
+
+// FacetValue indique combien de résultats donnerait la sélection d'une valeur
+type FacetValue struct {
+	Value    string `json:"value"`
+	Count    int    `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+// QueryResult contient les Digimons retenus et les compteurs par facette
+type QueryResult struct {
+	Digimons []Digimon               `json:"digimons"`
+	Total    int                     `json:"total"`
+	Facets   map[string][]FacetValue `json:"facets"`
+}
+
+// Noms des facettes retournées dans QueryResult.Facets
+const (
+	FacetLevels     = "levels"
+	FacetAttributes = "attributes"
+	FacetTypes      = "types"
+	FacetFields     = "fields"
+	FacetSkills     = "skills"
+	FacetXAntibody  = "xAntibody"
+)
+
+// facet associe un nom, un extracteur de valeurs et la sélection courante
+type facet struct {
+	name     string
+	values   func(d *Digimon) []string
+	selected []string
+}
+
+// RunQuery filtre les Digimons selon la requête et calcule les facettes.
+// Le compteur d'une valeur tient compte de toutes les autres facettes
+// sélectionnées, mais pas de la sienne : il correspond au nombre de
+// résultats obtenus en cochant cette case en plus.
+func RunQuery(digimons []Digimon, q DigimonQuery) *QueryResult {
+	facets := q.facets()
+
+	result := &QueryResult{
+		Digimons: []Digimon{},
+		Facets:   make(map[string][]FacetValue, len(facets)),
+	}
+	counts := make([]map[string]int, len(facets))
+	for i := range facets {
+		counts[i] = make(map[string]int)
+	}
+
+	for i := range digimons {
+		digimon := &digimons[i]
+
+		// Pour chaque facette, on note si le Digimon la satisfait
+		matches := make([]bool, len(facets))
+		failed := 0
+		for j, f := range facets {
+			matches[j] = matchesAny(f.values(digimon), f.selected)
+			if !matches[j] {
+				failed++
+			}
+		}
+
+		if failed == 0 {
+			result.Digimons = append(result.Digimons, *digimon)
+		}
+
+		// Compteurs : le Digimon compte pour une facette si toutes
+		// les autres facettes sont satisfaites
+		for j, f := range facets {
+			if failed > 1 || (failed == 1 && matches[j]) {
+				continue
+			}
+			for _, value := range uniqueValues(f.values(digimon)) {
+				counts[j][value]++
+			}
+		}
+	}
+
+	for j, f := range facets {
+		result.Facets[f.name] = facetValues(counts[j], f.selected)
+	}
+	result.Total = len(result.Digimons)
+
+	if !q.Sort.IsZero() {
+		sort.SliceStable(result.Digimons, func(i, j int) bool {
+			return q.Sort.compareDigimons(&result.Digimons[i], &result.Digimons[j]) < 0
+		})
+	}
+
+	return result
+}
+
+// IsEmpty indique si la requête ne contient aucun filtre
+func (q DigimonQuery) IsEmpty() bool {
+	return len(q.Levels) == 0 && len(q.Attributes) == 0 && len(q.Types) == 0 &&
+		len(q.Fields) == 0 && len(q.Skills) == 0 && q.XAntibody == nil
+}
+
+func (q DigimonQuery) facets() []facet {
+	xAntibody := []string{}
+	if q.XAntibody != nil {
+		xAntibody = append(xAntibody, strconv.FormatBool(*q.XAntibody))
+	}
+
+	return []facet{
+		{FacetLevels, func(d *Digimon) []string {
+			values := make([]string, 0, len(d.Levels))
+			for _, l := range d.Levels {
+				values = append(values, l.Level)
+			}
+			return values
+		}, q.Levels},
+		{FacetAttributes, func(d *Digimon) []string {
+			values := make([]string, 0, len(d.Attributes))
+			for _, a := range d.Attributes {
+				values = append(values, a.Attribute)
+			}
+			return values
+		}, q.Attributes},
+		{FacetTypes, func(d *Digimon) []string {
+			values := make([]string, 0, len(d.Types))
+			for _, t := range d.Types {
+				values = append(values, t.Type)
+			}
+			return values
+		}, q.Types},
+		{FacetFields, func(d *Digimon) []string {
+			values := make([]string, 0, len(d.Fields))
+			for _, f := range d.Fields {
+				values = append(values, f.Field)
+			}
+			return values
+		}, q.Fields},
+		{FacetSkills, func(d *Digimon) []string {
+			values := make([]string, 0, len(d.Skills))
+			for _, s := range d.Skills {
+				values = append(values, s.Skill)
+			}
+			return values
+		}, q.Skills},
+		{FacetXAntibody, func(d *Digimon) []string {
+			return []string{strconv.FormatBool(d.XAntibody)}
+		}, xAntibody},
+	}
+}
+
+// matchesAny indique si l'une des valeurs fait partie de la sélection
+// (insensible à la casse). Une sélection vide accepte tout.
+func matchesAny(values, selected []string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, value := range values {
+		for _, s := range selected {
+			if strings.EqualFold(value, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// facetValues convertit les compteurs en liste triée par valeur.
+// Les valeurs sélectionnées sans résultat restent visibles avec un compteur nul.
+func facetValues(counts map[string]int, selected []string) []FacetValue {
+	for _, s := range selected {
+		found := false
+		for value := range counts {
+			if strings.EqualFold(value, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			counts[s] = 0
+		}
+	}
+
+	values := make([]FacetValue, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, FacetValue{
+			Value:    value,
+			Count:    count,
+			Selected: matchesAny([]string{value}, selected) && len(selected) > 0,
+		})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Value < values[j].Value })
+	return values
+}
+
+func uniqueValues(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0:0]
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"time"
+)
+
+// ============================================================
+// POLITIQUE DE NOUVEL ESSAI
+// ============================================================
+
+// RetryPolicy paramètre les nouveaux essais des appels à l'API.
+// Seuls les GET (idempotents) passent par getJSON, donc tous peuvent être
+// rejoués ; seules les erreurs transitoires déclenchent un nouvel essai.
+type RetryPolicy struct {
+	MaxAttempts int           // Nombre total de tentatives (défaut: 3, 1 = aucun nouvel essai)
+	BaseDelay   time.Duration // Délai avant le deuxième essai, doublé ensuite (défaut: 200ms)
+	MaxDelay    time.Duration // Délai maximum entre deux essais (défaut: 5s)
+	Jitter      float64       // Part aléatoire du délai, entre 0 et 1 (défaut: 0.5)
+}
+
+// withDefaults complète les champs vides de la politique
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 200 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 5 * time.Second
+	}
+	if p.Jitter <= 0 || p.Jitter > 1 {
+		p.Jitter = 0.5
+	}
+	return p
+}
+
+// backoff calcule l'attente avant la tentative suivant attempt (1 = premier échec).
+// Le délai exponentiel est en partie tiré au hasard pour éviter que des
+// clients simultanés ne réessaient tous au même instant.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	fixed := time.Duration(float64(delay) * (1 - p.Jitter))
+	return fixed + time.Duration(rand.Float64()*float64(delay-fixed))
+}
+
+// isRetryable indique si une erreur de l'API est transitoire
+// (API indisponible, 5xx, délai dépassé, 429)
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrUpstreamUnavailable) ||
+		errors.Is(err, ErrTimeout) ||
+		errors.Is(err, ErrRateLimited)
+}
+
+// withRetry exécute call jusqu'à réussite ou épuisement des tentatives.
+// Le délai Retry-After renvoyé par l'API est respecté ; s'il dépasse MaxDelay
+// ou l'échéance du contexte, l'erreur est retournée sans attendre.
+func (p RetryPolicy) withRetry(ctx context.Context, endpoint string, call func() (int, error)) (int, error) {
+	for attempt := 1; ; attempt++ {
+		statusCode, err := call()
+		if err == nil || attempt >= p.MaxAttempts || !isRetryable(err) || errors.Is(err, ErrThrottled) || ctx.Err() != nil {
+			return statusCode, err
+		}
+
+		wait := p.backoff(attempt)
+		var upstream *UpstreamError
+		if errors.As(err, &upstream) && upstream.RetryAfter > 0 {
+			if upstream.RetryAfter > p.MaxDelay {
+				return statusCode, err
+			}
+			wait = max(wait, upstream.RetryAfter)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return statusCode, err
+		}
+
+		log.Printf("API - tentative %d/%d échouée pour %s (%v), nouvel essai dans %s",
+			attempt, p.MaxAttempts, endpoint, err, wait.Round(time.Millisecond))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return statusCode, err
+		case <-timer.C:
+		}
+	}
+}
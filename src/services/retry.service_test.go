@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeResponse est une réponse de l'API simulée
+type fakeResponse struct {
+	status     int
+	retryAfter string
+}
+
+// newRetryServer simule une API renvoyant les réponses données dans l'ordre
+// (la dernière est répétée) et compte les tentatives reçues
+func newRetryServer(t *testing.T, responses []fakeResponse) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(attempts.Add(1))
+		response := responses[min(n, len(responses))-1]
+
+		if response.retryAfter != "" {
+			w.Header().Set("Retry-After", response.retryAfter)
+		}
+		w.WriteHeader(response.status)
+		if response.status == http.StatusOK {
+			w.Write([]byte(`{"id":1,"name":"Botamon"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &attempts
+}
+
+func TestHTTPSourceRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []fakeResponse
+		wantAttempts int32
+		wantStatus   int
+		wantErr      error         // Catégorie attendue (nil = succès)
+		minElapsed   time.Duration // Attente minimum imposée par Retry-After
+	}{
+		{
+			name:         "503 puis 200",
+			responses:    []fakeResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			wantAttempts: 2,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "429 avec Retry-After puis 200",
+			responses:    []fakeResponse{{status: http.StatusTooManyRequests, retryAfter: "1"}, {status: http.StatusOK}},
+			wantAttempts: 2,
+			wantStatus:   http.StatusOK,
+			minElapsed:   time.Second,
+		},
+		{
+			name:         "429 avec Retry-After trop long",
+			responses:    []fakeResponse{{status: http.StatusTooManyRequests, retryAfter: "60"}, {status: http.StatusOK}},
+			wantAttempts: 1,
+			wantStatus:   http.StatusTooManyRequests,
+			wantErr:      ErrRateLimited,
+		},
+		{
+			name:         "toutes les tentatives échouent",
+			responses:    []fakeResponse{{status: http.StatusBadGateway}},
+			wantAttempts: 3,
+			wantStatus:   http.StatusBadGateway,
+			wantErr:      ErrUpstreamUnavailable,
+		},
+		{
+			name:         "404 sans nouvel essai",
+			responses:    []fakeResponse{{status: http.StatusNotFound}},
+			wantAttempts: 1,
+			wantStatus:   http.StatusNotFound,
+			wantErr:      ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, attempts := newRetryServer(t, tt.responses)
+			source := NewHTTPSource(HTTPSourceConfig{
+				BaseURL: server.URL,
+				Retry:   RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second},
+			})
+
+			start := time.Now()
+			digimon, status, err := source.GetDigimonByID(context.Background(), 1)
+			elapsed := time.Since(start)
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("tentatives = %d, attendu %d", got, tt.wantAttempts)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("code HTTP = %d, attendu %d", status, tt.wantStatus)
+			}
+			if elapsed < tt.minElapsed {
+				t.Errorf("durée = %s, attendu au moins %s (Retry-After)", elapsed, tt.minElapsed)
+			}
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("erreur inattendue: %v", err)
+				}
+				if digimon.Name != "Botamon" {
+					t.Errorf("Digimon = %q, attendu Botamon", digimon.Name)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("erreur = %v, attendu la catégorie %v", err, tt.wantErr)
+			}
+			var upstream *UpstreamError
+			if !errors.As(err, &upstream) || upstream.StatusCode != tt.wantStatus {
+				t.Errorf("erreur = %#v, attendu une *UpstreamError de code %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}.withDefaults()
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{attempt: 10, min: 500 * time.Millisecond, max: time.Second}, // Plafonné à MaxDelay
+		{attempt: 80, min: 500 * time.Millisecond, max: time.Second}, // Décalage hors bornes
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			if wait := policy.backoff(tt.attempt); wait < tt.min || wait > tt.max {
+				t.Fatalf("backoff(%d) = %s, attendu entre %s et %s", tt.attempt, wait, tt.min, tt.max)
+			}
+		}
+	}
+}
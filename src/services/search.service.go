@@ -0,0 +1,359 @@
+package services
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Types de correspondance d'un résultat de recherche, du plus fort au plus faible
+const (
+	MatchExact    = "exact"    // Nom identique (casse et accents ignorés)
+	MatchPrefix   = "prefix"   // Le nom commence par la recherche
+	MatchContains = "contains" // Le nom contient la recherche
+	MatchFuzzy    = "fuzzy"    // Nom proche (faute de frappe)
+)
+
+// minSimilarity est la similarité minimale (0 à 1) d'un résultat approché
+const minSimilarity = 0.7
+
+// ============================================================
+// INDEX DE RECHERCHE
+// ============================================================
+
+// SearchHit est un résultat de recherche classé par pertinence
+type SearchHit struct {
+	Digimon DigimonSummary `json:"digimon"`
+	Match   string         `json:"match"`
+	Score   float64        `json:"score"` // Entre 0 et 1
+}
+
+// SearchIndex permet de rechercher les Digimons par nom en tolérant
+// les différences de casse, les accents et les fautes de frappe
+type SearchIndex struct {
+	entries  []searchEntry
+	prefixes []prefixKey // Noms et mots des noms, triés pour l'autocomplétion
+}
+
+// prefixKey associe un nom normalisé (ou l'un de ses mots) à son entrée
+type prefixKey struct {
+	key   string
+	entry int  // Position dans entries
+	whole bool // true = nom complet, false = mot à l'intérieur du nom
+}
+
+// searchEntry est un nom indexé
+type searchEntry struct {
+	summary  DigimonSummary
+	folded   string   // Nom normalisé (voir FoldName)
+	tokens   []string // Mots du nom normalisé
+	trigrams map[string]struct{}
+}
+
+// NewSearchIndex indexe les noms des résumés fournis
+func NewSearchIndex(summaries []DigimonSummary) *SearchIndex {
+	index := &SearchIndex{entries: make([]searchEntry, 0, len(summaries))}
+	for _, summary := range summaries {
+		folded := FoldName(summary.Name)
+		if folded == "" {
+			continue
+		}
+		entry := searchEntry{
+			summary:  summary,
+			folded:   folded,
+			tokens:   strings.Fields(folded),
+			trigrams: trigrams(folded),
+		}
+
+		position := len(index.entries)
+		index.entries = append(index.entries, entry)
+		index.prefixes = append(index.prefixes, prefixKey{key: folded, entry: position, whole: true})
+		for _, token := range entry.tokens[1:] {
+			index.prefixes = append(index.prefixes, prefixKey{key: token, entry: position})
+		}
+	}
+
+	slices.SortFunc(index.prefixes, func(a, b prefixKey) int {
+		return strings.Compare(a.key, b.key)
+	})
+	return index
+}
+
+// Complete retourne au plus limit Digimons dont le nom, ou l'un des mots du
+// nom, commence par le texte saisi (autocomplétion). Les noms commençant par
+// le texte passent en premier, puis les plus courts.
+// La recherche dichotomique dans les clés triées évite de parcourir l'index.
+func (idx *SearchIndex) Complete(prefix string, limit int) []DigimonSummary {
+	folded := FoldName(prefix)
+	if folded == "" {
+		return []DigimonSummary{}
+	}
+
+	start, _ := slices.BinarySearchFunc(idx.prefixes, folded, func(key prefixKey, target string) int {
+		return strings.Compare(key.key, target)
+	})
+
+	// Une entrée peut correspondre par son nom et par un de ses mots :
+	// seule la meilleure correspondance est gardée
+	best := map[int]bool{}
+	for _, key := range idx.prefixes[start:] {
+		if !strings.HasPrefix(key.key, folded) {
+			break
+		}
+		best[key.entry] = best[key.entry] || key.whole
+	}
+
+	matches := make([]int, 0, len(best))
+	for entry := range best {
+		matches = append(matches, entry)
+	}
+	slices.SortFunc(matches, func(a, b int) int {
+		if best[a] != best[b] {
+			if best[a] {
+				return -1
+			}
+			return 1
+		}
+		ea, eb := idx.entries[a].summary, idx.entries[b].summary
+		if c := cmp.Compare(len(ea.Name), len(eb.Name)); c != 0 {
+			return c
+		}
+		return cmp.Compare(ea.ID, eb.ID)
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	summaries := make([]DigimonSummary, len(matches))
+	for i, entry := range matches {
+		summaries[i] = idx.entries[entry].summary
+	}
+	return summaries
+}
+
+// Search retourne au plus limit Digimons correspondant à la recherche,
+// du plus pertinent au moins pertinent (limit <= 0 : tous).
+// Les noms exacts passent avant les préfixes, puis les noms contenant la
+// recherche, puis les noms approchés (distance de Levenshtein et trigrammes),
+// ces derniers étant écartés si le nom exact existe.
+func (idx *SearchIndex) Search(query string, limit int) []SearchHit {
+	folded := FoldName(query)
+	if folded == "" {
+		return nil
+	}
+	queryTrigrams := trigrams(folded)
+
+	hits := []SearchHit{}
+	for i := range idx.entries {
+		if hit, ok := idx.entries[i].match(folded, queryTrigrams); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	slices.SortStableFunc(hits, func(a, b SearchHit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.Digimon.Name), len(b.Digimon.Name)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Digimon.ID, b.Digimon.ID)
+	})
+
+	// Quand le nom exact existe, les noms approchés ne sont que du bruit
+	if len(hits) > 0 && hits[0].Match == MatchExact {
+		hits = slices.DeleteFunc(hits, func(hit SearchHit) bool { return hit.Match == MatchFuzzy })
+	}
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// Suggest propose au plus limit noms proches de la recherche ("vouliez-vous
+// dire"). Rien n'est proposé si un Digimon porte exactement ce nom.
+func (idx *SearchIndex) Suggest(query string, limit int) []string {
+	hits := idx.Search(query, 0)
+	if len(hits) > 0 && hits[0].Match == MatchExact {
+		return nil
+	}
+
+	suggestions := []string{}
+	for _, hit := range hits {
+		if limit > 0 && len(suggestions) >= limit {
+			break
+		}
+		if !slices.Contains(suggestions, hit.Digimon.Name) {
+			suggestions = append(suggestions, hit.Digimon.Name)
+		}
+	}
+	return suggestions
+}
+
+// SearchIndex retourne l'index de recherche des noms du snapshot
+// (construit au premier appel)
+func (s *Snapshot) SearchIndex() *SearchIndex {
+	s.searchOnce.Do(func() {
+		summaries := make([]DigimonSummary, len(s.Digimons))
+		for i := range s.Digimons {
+			summaries[i] = s.Digimons[i].Summary(s.Source)
+		}
+		s.search = NewSearchIndex(summaries)
+	})
+	return s.search
+}
+
+// SearchDigimons recherche les Digimons du snapshot par nom. Les résultats
+// sont classés par pertinence, ou selon order s'il est renseigné.
+func (s *Snapshot) SearchDigimons(query string, order SortOrder) []SearchHit {
+	hits := s.SearchIndex().Search(query, 0)
+	if order.IsZero() {
+		return hits
+	}
+
+	slices.SortStableFunc(hits, func(a, b SearchHit) int {
+		return order.compare(s.sortKey(a.Digimon), s.sortKey(b.Digimon))
+	})
+	return hits
+}
+
+// sortKey retourne les valeurs de tri d'un résumé à partir du Digimon complet
+func (s *Snapshot) sortKey(summary DigimonSummary) sortKey {
+	if digimon, ok := s.Digimon(summary.ID); ok {
+		return digimonSortKey(digimon)
+	}
+	return sortKey{id: summary.ID, name: summary.Name}
+}
+
+// match évalue un nom indexé pour une recherche déjà normalisée
+func (e *searchEntry) match(query string, queryTrigrams map[string]struct{}) (SearchHit, bool) {
+	hit := SearchHit{Digimon: e.summary}
+	coverage := float64(len(query)) / float64(len(e.folded))
+
+	switch {
+	case e.folded == query:
+		hit.Match, hit.Score = MatchExact, 1
+	case strings.HasPrefix(e.folded, query):
+		hit.Match, hit.Score = MatchPrefix, 0.8+0.1*coverage
+	case strings.Contains(e.folded, query):
+		hit.Match, hit.Score = MatchContains, 0.7+0.1*coverage
+	default:
+		// Le nom complet, chacun de ses mots et son début (de la longueur de
+		// la recherche) sont comparés : "agumn" est proche de "agumon 2006".
+		// Le début n'est comparé qu'à partir de 4 lettres, pour éviter les
+		// rapprochements hasardeux sur les recherches courtes.
+		candidates := append([]string{e.folded}, e.tokens...)
+		name, length := []rune(e.folded), len([]rune(query))
+		if length >= 4 && len(name) > length {
+			candidates = append(candidates, string(name[:length]))
+		}
+
+		similarity := jaccard(queryTrigrams, e.trigrams)
+		for _, candidate := range candidates {
+			similarity = max(similarity, levenshteinSimilarity(query, candidate))
+		}
+		if similarity < minSimilarity {
+			return hit, false
+		}
+		hit.Match, hit.Score = MatchFuzzy, 0.7*similarity
+	}
+	return hit, true
+}
+
+// ============================================================
+// NORMALISATION ET SIMILARITÉ
+// ============================================================
+
+// foldedRunes remplace les lettres accentuées par leur lettre de base
+var foldedRunes = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'ß': "ss",
+}
+
+// FoldName normalise un nom pour la recherche : minuscules, accents retirés,
+// ponctuation remplacée par des espaces ("WarGreymon (X-Antibody)" devient
+// "wargreymon x antibody")
+func FoldName(name string) string {
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case foldedRunes[r] != "":
+			b.WriteString(foldedRunes[r])
+			space = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case !space:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// trigrams découpe un texte normalisé en trigrammes, bornes comprises
+func trigrams(text string) map[string]struct{} {
+	runes := []rune(" " + text + " ")
+	set := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+	return set
+}
+
+// jaccard mesure la part de trigrammes communs à deux textes (0 à 1)
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// levenshteinSimilarity convertit la distance d'édition en similarité (0 à 1)
+func levenshteinSimilarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein calcule le nombre minimum d'insertions, suppressions et
+// substitutions pour passer de a à b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
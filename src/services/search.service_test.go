@@ -0,0 +1,176 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+// newTestIndex indexe quelques noms représentatifs : variantes, accents,
+// noms de plusieurs mots
+func newTestIndex() *SearchIndex {
+	names := []string{
+		"Agumon", "Greymon", "WarGreymon", "WarGreymon (X-Antibody)", "Élecmon",
+		"Gabumon", "Agumon (2006)", "Agumon X", "Xuanwumon", "MetalGreymon",
+	}
+
+	summaries := make([]DigimonSummary, len(names))
+	for i, name := range names {
+		summaries[i] = DigimonSummary{ID: i + 1, Name: name}
+	}
+	return NewSearchIndex(summaries)
+}
+
+// hitNames retourne les noms des résultats, dans l'ordre
+func hitNames(hits []SearchHit) []string {
+	names := make([]string, len(hits))
+	for i, hit := range hits {
+		names[i] = hit.Digimon.Name
+	}
+	return names
+}
+
+func TestSearchIndexSearch(t *testing.T) {
+	index := newTestIndex()
+
+	tests := []struct {
+		name      string
+		query     string
+		limit     int
+		wantFirst string // Premier résultat attendu ("" = aucun résultat)
+		wantMatch string
+		wantCount int // Nombre de résultats attendu (-1 = non vérifié)
+	}{
+		{name: "faute de frappe", query: "Agumn", wantFirst: "Agumon", wantMatch: MatchFuzzy, wantCount: -1},
+		{name: "nom exact sans bruit approché", query: "agumon", wantFirst: "Agumon", wantMatch: MatchExact, wantCount: 3},
+		{name: "variante X-Antibody", query: "wargreymon x", wantFirst: "WarGreymon (X-Antibody)", wantMatch: MatchPrefix, wantCount: -1},
+		{name: "accents ignorés", query: "elecmon", wantFirst: "Élecmon", wantMatch: MatchExact, wantCount: 1},
+		{name: "casse et accents ignorés", query: "ÉLECMON", wantFirst: "Élecmon", wantMatch: MatchExact, wantCount: 1},
+		{name: "nom contenu", query: "greymon", wantFirst: "Greymon", wantMatch: MatchExact, wantCount: 4},
+		{name: "limite", query: "greymon", limit: 2, wantFirst: "Greymon", wantMatch: MatchExact, wantCount: 2},
+		{name: "aucun résultat", query: "zzzz", wantCount: 0},
+		{name: "recherche vide", query: " !? ", wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := index.Search(tt.query, tt.limit)
+
+			if tt.wantCount >= 0 && len(hits) != tt.wantCount {
+				t.Fatalf("Search(%q) = %v, attendu %d résultats", tt.query, hitNames(hits), tt.wantCount)
+			}
+			if tt.wantFirst == "" {
+				return
+			}
+			if len(hits) == 0 {
+				t.Fatalf("Search(%q) ne renvoie rien, attendu %s", tt.query, tt.wantFirst)
+			}
+			if hits[0].Digimon.Name != tt.wantFirst || hits[0].Match != tt.wantMatch {
+				t.Errorf("Search(%q)[0] = %s (%s), attendu %s (%s)",
+					tt.query, hits[0].Digimon.Name, hits[0].Match, tt.wantFirst, tt.wantMatch)
+			}
+			for i := 1; i < len(hits); i++ {
+				if hits[i].Score > hits[i-1].Score {
+					t.Errorf("Search(%q) mal classé: %v", tt.query, hitNames(hits))
+				}
+			}
+		})
+	}
+}
+
+func TestSearchIndexComplete(t *testing.T) {
+	index := newTestIndex()
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{name: "début du nom, plus courts d'abord", prefix: "war", want: []string{"WarGreymon", "WarGreymon (X-Antibody)"}},
+		{name: "nom complet avant mot du nom", prefix: "x", want: []string{"Xuanwumon", "Agumon X", "WarGreymon (X-Antibody)"}},
+		{name: "mot à l'intérieur du nom", prefix: "anti", want: []string{"WarGreymon (X-Antibody)"}},
+		{name: "accents ignorés", prefix: "ELEC", want: []string{"Élecmon"}},
+		{name: "limite", prefix: "agu", limit: 2, want: []string{"Agumon", "Agumon X"}},
+		{name: "aucun nom", prefix: "zz", want: []string{}},
+		{name: "saisie vide", prefix: " ", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := index.Complete(tt.prefix, tt.limit)
+			names := make([]string, len(got))
+			for i, summary := range got {
+				names[i] = summary.Name
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("Complete(%q, %d) = %v, attendu %v", tt.prefix, tt.limit, names, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIndexSuggest(t *testing.T) {
+	index := newTestIndex()
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "faute de frappe", query: "Agumn", limit: 1, want: []string{"Agumon"}},
+		{name: "nom exact", query: "Agumon", limit: 3, want: nil},
+		{name: "préfixe", query: "wargrey", limit: 2, want: []string{"WarGreymon", "WarGreymon (X-Antibody)"}},
+		{name: "rien de proche", query: "zzzz", limit: 3, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := index.Suggest(tt.query, tt.limit); !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest(%q, %d) = %v, attendu %v", tt.query, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "WarGreymon (X-Antibody)", want: "wargreymon x antibody"},
+		{name: "Élecmon", want: "elecmon"},
+		{name: "  Omnimon !! ", want: "omnimon"},
+		{name: "Œuf Ñ", want: "oeuf n"},
+		{name: "Agumon (2006)", want: "agumon 2006"},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := FoldName(tt.name); got != tt.want {
+			t.Errorf("FoldName(%q) = %q, attendu %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "agumon", b: "agumon", want: 0},
+		{a: "agumn", b: "agumon", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "", b: "abc", want: 3},
+		{a: "élec", b: "elec", want: 1}, // Distance en caractères, pas en octets
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, attendu %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := levenshtein(tt.b, tt.a); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, attendu %d (symétrie)", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// Paramètres des sessions
+const (
+	DefaultSessionTTL = 7 * 24 * time.Hour // Durée de vie d'une session inactive
+	sessionTokenSize  = 32                 // Octets aléatoires des identifiants et jetons CSRF
+	sessionPruneEvery = 1000               // Nettoyage des sessions expirées toutes les N créations
+)
+
+// ============================================================
+// SESSIONS
+// ============================================================
+
+// Session est la session d'un visiteur, connecté ou non. Chaque session a son
+// propre jeton CSRF, exigé par les formulaires qui modifient des données.
+type Session struct {
+	ID        string
+	UserID    int // 0 tant que le visiteur n'est pas connecté
+	CSRFToken string
+	ExpiresAt time.Time
+}
+
+// Authenticated indique si un utilisateur est connecté dans la session
+func (s *Session) Authenticated() bool {
+	return s.UserID != 0
+}
+
+// SessionStore conserve les sessions en mémoire : elles sont perdues au
+// redémarrage du serveur, ce qui déconnecte les utilisateurs.
+// L'expiration est glissante : chaque utilisation prolonge la session.
+type SessionStore struct {
+	ttl      time.Duration
+	mu       sync.Mutex
+	sessions map[string]*Session
+	created  int // Sessions créées depuis le dernier nettoyage
+}
+
+// NewSessionStore crée un stockage de sessions de durée de vie donnée
+// (DefaultSessionTTL si ttl <= 0)
+func NewSessionStore(ttl time.Duration) *SessionStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &SessionStore{ttl: ttl, sessions: map[string]*Session{}}
+}
+
+// TTL retourne la durée de vie des sessions
+func (s *SessionStore) TTL() time.Duration {
+	return s.ttl
+}
+
+// Start ouvre une session anonyme
+func (s *SessionStore) Start() *Session {
+	return s.create(0)
+}
+
+// Get retourne une copie de la session d'identifiant donné et prolonge sa
+// durée de vie (false si elle est inconnue ou expirée)
+func (s *SessionStore) Get(id string) (*Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(session.ExpiresAt) {
+		delete(s.sessions, id)
+		return nil, false
+	}
+
+	session.ExpiresAt = time.Now().Add(s.ttl)
+	result := *session
+	return &result, true
+}
+
+// Login connecte un utilisateur : l'ancienne session est détruite et une
+// nouvelle est ouverte, avec un nouvel identifiant et un nouveau jeton CSRF
+// (protection contre la fixation de session)
+func (s *SessionStore) Login(previous *Session, userID int) *Session {
+	s.Destroy(previous)
+	return s.create(userID)
+}
+
+// Destroy supprime une session
+func (s *SessionStore) Destroy(session *Session) {
+	if session == nil {
+		return
+	}
+	s.mu.Lock()
+	delete(s.sessions, session.ID)
+	s.mu.Unlock()
+}
+
+// create enregistre une nouvelle session et nettoie régulièrement les
+// sessions expirées
+func (s *SessionStore) create(userID int) *Session {
+	session := &Session{
+		ID:        randomToken(sessionTokenSize),
+		UserID:    userID,
+		CSRFToken: randomToken(sessionTokenSize),
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.ID] = session
+	if s.created++; s.created >= sessionPruneEvery {
+		s.created = 0
+		now := time.Now()
+		for id, existing := range s.sessions {
+			if now.After(existing.ExpiresAt) {
+				delete(s.sessions, id)
+			}
+		}
+	}
+
+	result := *session
+	return &result
+}
+
+// randomToken retourne un jeton aléatoire de size octets, encodé pour les
+// URL et les cookies
+func randomToken(size int) string {
+	token := make([]byte, size)
+	rand.Read(token) // Ne retourne jamais d'erreur (voir crypto/rand)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// ============================================================
+// CONTEXTE DE REQUÊTE
+// ============================================================
+
+type sessionKey struct{}
+type userKey struct{}
+
+// requestSession est la session d'une requête. Un visiteur sans session n'en
+// reçoit une qu'à la demande (voir StartSession) : les simples consultations
+// (API, fichiers statiques, robots) n'ouvrent pas de session.
+type requestSession struct {
+	mu      sync.Mutex
+	session *Session        // nil tant qu'aucune session n'est ouverte
+	start   func() *Session // Ouvre une session et l'envoie au navigateur
+}
+
+// WithSession attache au contexte d'une requête la session du visiteur
+// (nil s'il n'en a pas) et la fonction qui en ouvre une à la demande
+func WithSession(ctx context.Context, session *Session, start func() *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &requestSession{session: session, start: start})
+}
+
+// SessionFrom retourne la session attachée au contexte (false si le visiteur
+// n'en a pas encore)
+func SessionFrom(ctx context.Context) (*Session, bool) {
+	current, ok := ctx.Value(sessionKey{}).(*requestSession)
+	if !ok {
+		return nil, false
+	}
+
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	return current.session, current.session != nil
+}
+
+// StartSession retourne la session attachée au contexte, ouverte au besoin
+// (false hors d'une requête gérée par les sessions)
+func StartSession(ctx context.Context) (*Session, bool) {
+	current, ok := ctx.Value(sessionKey{}).(*requestSession)
+	if !ok {
+		return nil, false
+	}
+
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	if current.session == nil {
+		current.session = current.start()
+	}
+	return current.session, true
+}
+
+// WithUser attache l'utilisateur connecté au contexte d'une requête
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// CurrentUser retourne l'utilisateur connecté (false pour un visiteur anonyme)
+func CurrentUser(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userKey{}).(*User)
+	return user, ok
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionExpiry(t *testing.T) {
+	store := NewSessionStore(50 * time.Millisecond)
+	session := store.Start()
+
+	// Chaque utilisation prolonge la session
+	for i := 0; i < 3; i++ {
+		time.Sleep(30 * time.Millisecond)
+		if _, ok := store.Get(session.ID); !ok {
+			t.Fatalf("session expirée après %d utilisations, attendu une expiration glissante", i)
+		}
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if _, ok := store.Get(session.ID); ok {
+		t.Error("session encore valide après sa durée de vie")
+	}
+}
+
+func TestSessionLogin(t *testing.T) {
+	store := NewSessionStore(time.Hour)
+	anonymous := store.Start()
+
+	session := store.Login(anonymous, 7)
+	if session.ID == anonymous.ID {
+		t.Error("identifiant conservé à la connexion, attendu un nouvel identifiant")
+	}
+	if session.CSRFToken == anonymous.CSRFToken {
+		t.Error("jeton CSRF conservé à la connexion, attendu un nouveau jeton")
+	}
+	if !session.Authenticated() || session.UserID != 7 {
+		t.Errorf("session = %+v, attendu l'utilisateur 7", session)
+	}
+
+	if _, ok := store.Get(anonymous.ID); ok {
+		t.Error("ancienne session encore valide après la connexion")
+	}
+	if got, ok := store.Get(session.ID); !ok || got.UserID != 7 {
+		t.Errorf("Get(nouvelle session) = %+v, %t, attendu l'utilisateur 7", got, ok)
+	}
+
+	store.Destroy(session)
+	if _, ok := store.Get(session.ID); ok {
+		t.Error("session encore valide après sa destruction")
+	}
+}
+
+func TestSessionUnknown(t *testing.T) {
+	store := NewSessionStore(time.Hour)
+	store.Start()
+
+	if _, ok := store.Get("inconnu"); ok {
+		t.Error("Get(identifiant inconnu) = true, attendu false")
+	}
+}
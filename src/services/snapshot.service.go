@@ -0,0 +1,441 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrCatalogUnavailable est retournée quand aucun snapshot n'est chargé.
+// Elle est classée comme ErrUpstreamUnavailable.
+var ErrCatalogUnavailable = fmt.Errorf("catalogue local indisponible: %w", ErrUpstreamUnavailable)
+
+// ============================================================
+// SOURCE HORS-LIGNE
+// ============================================================
+
+// SnapshotSource sert les données depuis le catalogue local, sans aucun
+// accès réseau. Elle suit les rechargements du CatalogStore.
+type SnapshotSource struct {
+	catalog *CatalogStore
+}
+
+// NewSnapshotSource crée une source hors-ligne adossée au catalogue
+func NewSnapshotSource(catalog *CatalogStore) *SnapshotSource {
+	return &SnapshotSource{catalog: catalog}
+}
+
+// GetDigimonByID récupère un Digimon du snapshot par son ID
+func (s *SnapshotSource) GetDigimonByID(ctx context.Context, id int) (*Digimon, int, error) {
+	snapshot, statusCode, err := s.snapshot()
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	if digimon, ok := snapshot.Digimon(id); ok {
+		return digimon, http.StatusOK, nil
+	}
+	return nil, http.StatusNotFound, fmt.Errorf("Digimon %d introuvable dans le snapshot: %w", id, ErrNotFound)
+}
+
+// HydrateDigimons complète les résumés d'une liste depuis le snapshot. Les
+// Digimons sont déjà en mémoire : ni appel réseau, ni parallélisme.
+func (s *SnapshotSource) HydrateDigimons(ctx context.Context, summaries []DigimonSummary) ([]HydratedDigimon, error) {
+	results := make([]HydratedDigimon, len(summaries))
+	for i, summary := range summaries {
+		digimon, _, err := s.GetDigimonByID(ctx, summary.ID)
+		results[i] = HydratedDigimon{Summary: summary, Digimon: digimon, Err: err}
+	}
+	return results, hydrationFailures(results)
+}
+
+// GetDigimonByName récupère un Digimon du snapshot par son nom (insensible à la casse)
+func (s *SnapshotSource) GetDigimonByName(ctx context.Context, name string) (*Digimon, int, error) {
+	snapshot, statusCode, err := s.snapshot()
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	for i := range snapshot.Digimons {
+		if strings.EqualFold(snapshot.Digimons[i].Name, name) {
+			return &snapshot.Digimons[i], http.StatusOK, nil
+		}
+	}
+	return nil, http.StatusNotFound, fmt.Errorf("Digimon %q introuvable dans le snapshot: %w", name, ErrNotFound)
+}
+
+// GetAllDigimons applique localement les mêmes filtres que l'API puis pagine.
+// Contrairement à l'API, le tri éventuel porte sur tous les résultats et
+// non sur la seule page retournée.
+func (s *SnapshotSource) GetAllDigimons(ctx context.Context, opts *DigimonListOptions) (*DigimonListResponse, int, error) {
+	snapshot, statusCode, err := s.snapshot()
+	if err != nil {
+		return nil, statusCode, err
+	}
+	if opts == nil {
+		opts = &DigimonListOptions{}
+	}
+
+	matches := []*Digimon{}
+	for i := range snapshot.Digimons {
+		if matchesListOptions(&snapshot.Digimons[i], opts) {
+			matches = append(matches, &snapshot.Digimons[i])
+		}
+	}
+	sortDigimons(matches, opts.Sort)
+
+	summaries := make([]DigimonSummary, len(matches))
+	for i, digimon := range matches {
+		summaries[i] = digimon.Summary(snapshot.Source)
+	}
+
+	list := paginateSummaries(summaries, opts.Page, opts.PageSize)
+	list.Pageable.Sort = Sort{Sorted: !opts.Sort.IsZero(), Unsorted: opts.Sort.IsZero(), Empty: opts.Sort.IsZero()}
+	return list, http.StatusOK, nil
+}
+
+// GetAttributeByID reconstruit un attribut et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetAttributeByID(ctx context.Context, id int) (*Attribute, int, error) {
+	ref, digimons, statusCode, err := s.collect(attributeRefs, refByID(id), "attribut "+fmt.Sprint(id))
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Attribute{ID: ref.id, Attribute: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetAttributeByName reconstruit un attribut et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetAttributeByName(ctx context.Context, name string) (*Attribute, int, error) {
+	ref, digimons, statusCode, err := s.collect(attributeRefs, refByName(name), "attribut "+name)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Attribute{ID: ref.id, Attribute: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetLevelByID reconstruit un niveau et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetLevelByID(ctx context.Context, id int) (*Level, int, error) {
+	ref, digimons, statusCode, err := s.collect(levelRefs, refByID(id), "niveau "+fmt.Sprint(id))
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Level{ID: ref.id, Level: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetLevelByName reconstruit un niveau et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetLevelByName(ctx context.Context, name string) (*Level, int, error) {
+	ref, digimons, statusCode, err := s.collect(levelRefs, refByName(name), "niveau "+name)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Level{ID: ref.id, Level: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetTypeByID reconstruit un type et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetTypeByID(ctx context.Context, id int) (*Type, int, error) {
+	ref, digimons, statusCode, err := s.collect(typeRefs, refByID(id), "type "+fmt.Sprint(id))
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Type{ID: ref.id, Type: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetTypeByName reconstruit un type et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetTypeByName(ctx context.Context, name string) (*Type, int, error) {
+	ref, digimons, statusCode, err := s.collect(typeRefs, refByName(name), "type "+name)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Type{ID: ref.id, Type: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetFieldByID reconstruit un champ et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetFieldByID(ctx context.Context, id int) (*Field, int, error) {
+	ref, digimons, statusCode, err := s.collect(fieldRefs, refByID(id), "champ "+fmt.Sprint(id))
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Field{ID: ref.id, Field: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetFieldByName reconstruit un champ et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetFieldByName(ctx context.Context, name string) (*Field, int, error) {
+	ref, digimons, statusCode, err := s.collect(fieldRefs, refByName(name), "champ "+name)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Field{ID: ref.id, Field: ref.name, Digimons: digimons}, statusCode, nil
+}
+
+// GetSkillByID reconstruit une compétence et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetSkillByID(ctx context.Context, id int) (*Skill, int, error) {
+	ref, digimons, statusCode, err := s.collect(skillRefs, refByID(id), "compétence "+fmt.Sprint(id))
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Skill{ID: ref.id, Skill: ref.name, Description: ref.description, Digimons: digimons}, statusCode, nil
+}
+
+// GetSkillByName reconstruit une compétence et ses Digimons depuis le snapshot
+func (s *SnapshotSource) GetSkillByName(ctx context.Context, name string) (*Skill, int, error) {
+	ref, digimons, statusCode, err := s.collect(skillRefs, refByName(name), "compétence "+name)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &Skill{ID: ref.id, Skill: ref.name, Description: ref.description, Digimons: digimons}, statusCode, nil
+}
+
+// resourceRef est une référence à une ressource (attribut, niveau...) portée par un Digimon
+type resourceRef struct {
+	id          int
+	name        string
+	description string
+}
+
+// collect retourne la première ressource correspondante et tous les Digimons qui la portent
+func (s *SnapshotSource) collect(refs func(d *Digimon) []resourceRef, match func(resourceRef) bool, label string) (resourceRef, []DigimonSummary, int, error) {
+	snapshot, statusCode, err := s.snapshot()
+	if err != nil {
+		return resourceRef{}, nil, statusCode, err
+	}
+
+	var found *resourceRef
+	digimons := []DigimonSummary{}
+	for i := range snapshot.Digimons {
+		for _, ref := range refs(&snapshot.Digimons[i]) {
+			if !match(ref) {
+				continue
+			}
+			if found == nil {
+				found = &ref
+			}
+			digimons = append(digimons, snapshot.Digimons[i].Summary(snapshot.Source))
+			break
+		}
+	}
+
+	if found == nil {
+		return resourceRef{}, nil, http.StatusNotFound, fmt.Errorf("%s introuvable dans le snapshot: %w", label, ErrNotFound)
+	}
+	return *found, digimons, http.StatusOK, nil
+}
+
+func refByID(id int) func(resourceRef) bool {
+	return func(ref resourceRef) bool { return ref.id == id }
+}
+
+func refByName(name string) func(resourceRef) bool {
+	return func(ref resourceRef) bool { return strings.EqualFold(ref.name, name) }
+}
+
+func attributeRefs(d *Digimon) []resourceRef {
+	refs := make([]resourceRef, 0, len(d.Attributes))
+	for _, a := range d.Attributes {
+		refs = append(refs, resourceRef{id: a.ID, name: a.Attribute})
+	}
+	return refs
+}
+
+func levelRefs(d *Digimon) []resourceRef {
+	refs := make([]resourceRef, 0, len(d.Levels))
+	for _, l := range d.Levels {
+		refs = append(refs, resourceRef{id: l.ID, name: l.Level})
+	}
+	return refs
+}
+
+func typeRefs(d *Digimon) []resourceRef {
+	refs := make([]resourceRef, 0, len(d.Types))
+	for _, t := range d.Types {
+		refs = append(refs, resourceRef{id: t.ID, name: t.Type})
+	}
+	return refs
+}
+
+func fieldRefs(d *Digimon) []resourceRef {
+	refs := make([]resourceRef, 0, len(d.Fields))
+	for _, f := range d.Fields {
+		refs = append(refs, resourceRef{id: f.ID, name: f.Field})
+	}
+	return refs
+}
+
+func skillRefs(d *Digimon) []resourceRef {
+	refs := make([]resourceRef, 0, len(d.Skills))
+	for _, s := range d.Skills {
+		refs = append(refs, resourceRef{id: s.ID, name: s.Skill, description: s.Description})
+	}
+	return refs
+}
+
+// ListResources reconstruit la liste d'un type de ressources depuis le snapshot
+func (s *SnapshotSource) ListResources(ctx context.Context, kind string, page, pageSize int) (*ResourceListResponse, int, error) {
+	snapshot, statusCode, err := s.snapshot()
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	var refs func(d *Digimon) []resourceRef
+	switch kind {
+	case ResourceLevel:
+		refs = levelRefs
+	case ResourceAttribute:
+		refs = attributeRefs
+	case ResourceType:
+		refs = typeRefs
+	case ResourceField:
+		refs = fieldRefs
+	default:
+		return nil, http.StatusNotFound, fmt.Errorf("ressource %q inconnue: %w", kind, ErrNotFound)
+	}
+
+	// Ressources distinctes, triées par ID
+	seen := map[int]bool{}
+	resources := []ResourceSummary{}
+	for i := range snapshot.Digimons {
+		for _, ref := range refs(&snapshot.Digimons[i]) {
+			if seen[ref.id] {
+				continue
+			}
+			seen[ref.id] = true
+			resources = append(resources, ResourceSummary{
+				ID:   ref.id,
+				Name: ref.name,
+				Href: fmt.Sprintf("%s/%s/%d", strings.TrimRight(snapshot.Source, "/"), kind, ref.id),
+			})
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+
+	if pageSize <= 0 {
+		pageSize = 5
+	}
+	totalPages := (len(resources) + pageSize - 1) / pageSize
+	page = max(page, 0)
+	fields := Paginate(resources, page, pageSize)
+
+	return &ResourceListResponse{
+		Content: ResourceListContent{
+			Name:   kind,
+			Fields: fields,
+		},
+		Pageable: ResourcePageable{
+			CurrentPage:    page,
+			ElementsOnPage: len(fields),
+			TotalElements:  len(resources),
+			TotalPages:     totalPages,
+		},
+	}, http.StatusOK, nil
+}
+
+func (s *SnapshotSource) snapshot() (*Snapshot, int, error) {
+	snapshot, ok := s.catalog.Snapshot()
+	if !ok {
+		return nil, http.StatusServiceUnavailable, ErrCatalogUnavailable
+	}
+	return snapshot, http.StatusOK, nil
+}
+
+// ============================================================
+// UTILITAIRES
+// ============================================================
+
+// Summary retourne la version simplifiée du Digimon, telle que dans les listes.
+// baseURL sert à construire le lien vers la ressource (peut être vide).
+func (d *Digimon) Summary(baseURL string) DigimonSummary {
+	summary := DigimonSummary{
+		ID:   d.ID,
+		Name: d.Name,
+	}
+	if baseURL != "" {
+		summary.Href = fmt.Sprintf("%s/digimon/%d", strings.TrimRight(baseURL, "/"), d.ID)
+	}
+	summary.Image = d.MainImage()
+	return summary
+}
+
+// MainImage retourne l'URL de la première image du Digimon (vide si aucune)
+func (d *Digimon) MainImage() string {
+	if len(d.Images) == 0 {
+		return ""
+	}
+	return d.Images[0].Href
+}
+
+// matchesListOptions reproduit les filtres de l'endpoint /digimon de l'API
+func matchesListOptions(digimon *Digimon, opts *DigimonListOptions) bool {
+	if opts.Name != "" {
+		if opts.Exact {
+			if !strings.EqualFold(digimon.Name, opts.Name) {
+				return false
+			}
+		} else if !strings.Contains(strings.ToLower(digimon.Name), strings.ToLower(opts.Name)) {
+			return false
+		}
+	}
+
+	if opts.XAntibody != nil && digimon.XAntibody != *opts.XAntibody {
+		return false
+	}
+
+	if opts.Attribute != "" {
+		found := false
+		for _, a := range digimon.Attributes {
+			if strings.EqualFold(a.Attribute, opts.Attribute) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if opts.Level != "" {
+		found := false
+		for _, l := range digimon.Levels {
+			if strings.EqualFold(l.Level, opts.Level) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// paginateSummaries construit une réponse paginée au format de l'API
+func paginateSummaries(items []DigimonSummary, page, pageSize int) *DigimonListResponse {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	totalPages := (len(items) + pageSize - 1) / pageSize
+	start := pageOffset(len(items), page, pageSize)
+	content := Paginate(items, page, pageSize)
+
+	return &DigimonListResponse{
+		Content: content,
+		Pageable: Pageable{
+			PageNumber: page,
+			PageSize:   pageSize,
+			Offset:     start,
+			Paged:      true,
+		},
+		TotalElements:    len(items),
+		TotalPages:       totalPages,
+		First:            page == 0,
+		Last:             page >= totalPages-1,
+		Size:             pageSize,
+		Number:           page,
+		NumberOfElements: len(content),
+		Empty:            len(content) == 0,
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Champs de tri acceptés par ?sort=
+const (
+	SortByID        = "id"
+	SortByName      = "name"
+	SortByLevel     = "level"
+	SortByAttribute = "attribute"
+)
+
+// SortFields liste les champs de tri dans l'ordre d'affichage
+var SortFields = []string{SortByName, SortByID, SortByLevel, SortByAttribute}
+
+// levelOrder donne le rang des niveaux, du plus jeune au plus évolué
+var levelOrder = map[string]int{
+	"fresh":       0,
+	"in-training": 1,
+	"rookie":      2,
+	"champion":    3,
+	"armor":       4,
+	"ultimate":    5,
+	"mega":        6,
+	"ultra":       7,
+	"hybrid":      8,
+}
+
+// ============================================================
+// ORDRE DE TRI
+// ============================================================
+
+// SortOrder décrit un tri demandé via ?sort=&dir=.
+// La valeur zéro signifie l'ordre naturel de la source (par ID).
+type SortOrder struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSortOrder valide les paramètres sort (name, id, level, attribute)
+// et dir (asc par défaut, desc)
+func ParseSortOrder(field, dir string) (SortOrder, error) {
+	field = strings.ToLower(strings.TrimSpace(field))
+	dir = strings.ToLower(strings.TrimSpace(dir))
+
+	if field == "" {
+		return SortOrder{}, nil
+	}
+	if !slices.Contains(SortFields, field) {
+		return SortOrder{}, fmt.Errorf("tri %q invalide (name, id, level ou attribute)", field)
+	}
+
+	switch dir {
+	case "", "asc":
+		return SortOrder{Field: field}, nil
+	case "desc":
+		return SortOrder{Field: field, Desc: true}, nil
+	}
+	return SortOrder{}, fmt.Errorf("sens de tri %q invalide (asc ou desc)", dir)
+}
+
+// IsZero indique qu'aucun tri n'est demandé
+func (o SortOrder) IsZero() bool {
+	return o.Field == ""
+}
+
+// Dir retourne le sens du tri ("asc" ou "desc")
+func (o SortOrder) Dir() string {
+	if o.Desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// sortKey regroupe les valeurs utilisables pour trier un Digimon
+type sortKey struct {
+	id         int
+	name       string
+	levels     []string
+	attributes []string
+}
+
+// compare compare deux Digimons selon l'ordre demandé.
+// Les Digimons sans valeur pour le champ trié sont toujours placés en
+// dernier, et l'ID départage les égalités pour un ordre stable.
+func (o SortOrder) compare(a, b sortKey) int {
+	var result int
+	switch o.Field {
+	case SortByName:
+		result = cmp.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+	case SortByLevel, SortByAttribute:
+		first, second := a.levels, b.levels
+		if o.Field == SortByAttribute {
+			first, second = a.attributes, b.attributes
+		}
+		if len(first) == 0 || len(second) == 0 {
+			if missing := cmp.Compare(len(second), len(first)); missing != 0 {
+				return missing
+			}
+			break
+		}
+		if o.Field == SortByLevel {
+			result = cmp.Compare(levelRank(first[0]), levelRank(second[0]))
+		}
+		if result == 0 {
+			result = cmp.Compare(strings.ToLower(first[0]), strings.ToLower(second[0]))
+		}
+	}
+
+	if o.Desc {
+		result = -result
+	}
+	if result == 0 {
+		result = cmp.Compare(a.id, b.id)
+	}
+	return result
+}
+
+// levelRank retourne le rang d'un niveau (les niveaux inconnus en dernier)
+func levelRank(level string) int {
+	if rank, ok := levelOrder[strings.ToLower(level)]; ok {
+		return rank
+	}
+	return len(levelOrder)
+}
+
+// sortDigimons trie des Digimons complets sur place selon l'ordre demandé
+func sortDigimons(digimons []*Digimon, order SortOrder) {
+	if order.IsZero() {
+		return
+	}
+	slices.SortStableFunc(digimons, order.compareDigimons)
+}
+
+// compareDigimons compare deux Digimons complets selon l'ordre demandé
+func (o SortOrder) compareDigimons(a, b *Digimon) int {
+	return o.compare(digimonSortKey(a), digimonSortKey(b))
+}
+
+func digimonSortKey(digimon *Digimon) sortKey {
+	key := sortKey{id: digimon.ID, name: digimon.Name}
+	for _, level := range digimon.Levels {
+		key.levels = append(key.levels, level.Level)
+	}
+	for _, attribute := range digimon.Attributes {
+		key.attributes = append(key.attributes, attribute.Attribute)
+	}
+	return key
+}
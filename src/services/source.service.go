@@ -0,0 +1,390 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAPIBaseURL est l'URL de base de l'API Digimon publique
+const DefaultAPIBaseURL = "https://digi-api.com/api/v1"
+
+// Configuration par défaut de l'API
+const defaultTimeout = 10 * time.Second
+
+// ============================================================
+// INTERFACE DE SOURCE
+// ============================================================
+
+// DigimonSource regroupe toutes les lectures possibles sur les données Digimon.
+// L'implémentation HTTP interroge digi-api.com, mais n'importe quelle autre
+// source (serveur local, données en mémoire...) peut être injectée à la place.
+type DigimonSource interface {
+	GetDigimonByID(ctx context.Context, id int) (*Digimon, int, error)
+	GetDigimonByName(ctx context.Context, name string) (*Digimon, int, error)
+	GetAllDigimons(ctx context.Context, opts *DigimonListOptions) (*DigimonListResponse, int, error)
+	GetAttributeByID(ctx context.Context, id int) (*Attribute, int, error)
+	GetAttributeByName(ctx context.Context, name string) (*Attribute, int, error)
+	GetLevelByID(ctx context.Context, id int) (*Level, int, error)
+	GetLevelByName(ctx context.Context, name string) (*Level, int, error)
+	GetTypeByID(ctx context.Context, id int) (*Type, int, error)
+	GetTypeByName(ctx context.Context, name string) (*Type, int, error)
+	GetFieldByID(ctx context.Context, id int) (*Field, int, error)
+	GetFieldByName(ctx context.Context, name string) (*Field, int, error)
+	GetSkillByID(ctx context.Context, id int) (*Skill, int, error)
+	GetSkillByName(ctx context.Context, name string) (*Skill, int, error)
+	ListResources(ctx context.Context, kind string, page, pageSize int) (*ResourceListResponse, int, error)
+}
+
+// ============================================================
+// IMPLÉMENTATION HTTP
+// ============================================================
+
+// HTTPSourceConfig contient les paramètres du client HTTP
+type HTTPSourceConfig struct {
+	BaseURL   string            // URL de base de l'API (défaut: digi-api.com)
+	Timeout   time.Duration     // Timeout par requête (défaut: 10s)
+	Transport http.RoundTripper // Transport personnalisé (nil = http.DefaultTransport)
+	Retry     RetryPolicy       // Nouveaux essais sur erreur transitoire
+	Breaker   BreakerConfig     // Coupure des appels quand l'API est en panne
+	Limiter   LimiterConfig     // Débit maximum d'appels vers l'API
+}
+
+// HTTPSource interroge une API compatible digi-api.com
+type HTTPSource struct {
+	baseURL string
+	client  *http.Client
+	retry   RetryPolicy
+	breaker *CircuitBreaker
+	limiter *RateLimiter
+}
+
+// NewHTTPSource crée une source HTTP à partir de la configuration.
+// Les champs vides prennent leur valeur par défaut.
+func NewHTTPSource(cfg HTTPSourceConfig) *HTTPSource {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultAPIBaseURL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &HTTPSource{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: cfg.Transport,
+		},
+		retry:   cfg.Retry.withDefaults(),
+		breaker: NewCircuitBreaker(cfg.Breaker),
+		limiter: NewRateLimiter(cfg.Limiter),
+	}
+}
+
+// BaseURL retourne l'URL de base utilisée par la source
+func (s *HTTPSource) BaseURL() string {
+	return s.baseURL
+}
+
+// GetDigimonByID récupère un Digimon spécifique par son ID
+func (s *HTTPSource) GetDigimonByID(ctx context.Context, id int) (*Digimon, int, error) {
+	var digimon Digimon
+	statusCode, err := s.getJSON(ctx, fmt.Sprintf("%s/digimon/%d", s.baseURL, id), &digimon)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &digimon, statusCode, nil
+}
+
+// GetDigimonByName récupère un Digimon spécifique par son nom
+func (s *HTTPSource) GetDigimonByName(ctx context.Context, name string) (*Digimon, int, error) {
+	var digimon Digimon
+	statusCode, err := s.getJSON(ctx, fmt.Sprintf("%s/digimon/%s", s.baseURL, url.PathEscape(name)), &digimon)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &digimon, statusCode, nil
+}
+
+// GetAllDigimons récupère la liste paginée des Digimons avec options de filtrage
+func (s *HTTPSource) GetAllDigimons(ctx context.Context, opts *DigimonListOptions) (*DigimonListResponse, int, error) {
+	endpoint := fmt.Sprintf("%s/digimon", s.baseURL)
+	if query := opts.query().Encode(); query != "" {
+		endpoint += "?" + query
+	}
+
+	var listResponse DigimonListResponse
+	statusCode, err := s.getJSON(ctx, endpoint, &listResponse)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &listResponse, statusCode, nil
+}
+
+// GetAttributeByID récupère un attribut par son ID
+func (s *HTTPSource) GetAttributeByID(ctx context.Context, id int) (*Attribute, int, error) {
+	return s.fetchAttribute(ctx, fmt.Sprintf("%s/attribute/%d", s.baseURL, id))
+}
+
+// GetAttributeByName récupère un attribut par son nom
+func (s *HTTPSource) GetAttributeByName(ctx context.Context, name string) (*Attribute, int, error) {
+	return s.fetchAttribute(ctx, fmt.Sprintf("%s/attribute/%s", s.baseURL, url.PathEscape(name)))
+}
+
+func (s *HTTPSource) fetchAttribute(ctx context.Context, endpoint string) (*Attribute, int, error) {
+	var attribute Attribute
+	statusCode, err := s.getJSON(ctx, endpoint, &attribute)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &attribute, statusCode, nil
+}
+
+// GetLevelByID récupère un niveau par son ID
+func (s *HTTPSource) GetLevelByID(ctx context.Context, id int) (*Level, int, error) {
+	return s.fetchLevel(ctx, fmt.Sprintf("%s/level/%d", s.baseURL, id))
+}
+
+// GetLevelByName récupère un niveau par son nom
+func (s *HTTPSource) GetLevelByName(ctx context.Context, name string) (*Level, int, error) {
+	return s.fetchLevel(ctx, fmt.Sprintf("%s/level/%s", s.baseURL, url.PathEscape(name)))
+}
+
+func (s *HTTPSource) fetchLevel(ctx context.Context, endpoint string) (*Level, int, error) {
+	var level Level
+	statusCode, err := s.getJSON(ctx, endpoint, &level)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &level, statusCode, nil
+}
+
+// GetTypeByID récupère un type par son ID
+func (s *HTTPSource) GetTypeByID(ctx context.Context, id int) (*Type, int, error) {
+	return s.fetchType(ctx, fmt.Sprintf("%s/type/%d", s.baseURL, id))
+}
+
+// GetTypeByName récupère un type par son nom
+func (s *HTTPSource) GetTypeByName(ctx context.Context, name string) (*Type, int, error) {
+	return s.fetchType(ctx, fmt.Sprintf("%s/type/%s", s.baseURL, url.PathEscape(name)))
+}
+
+func (s *HTTPSource) fetchType(ctx context.Context, endpoint string) (*Type, int, error) {
+	var digimonType Type
+	statusCode, err := s.getJSON(ctx, endpoint, &digimonType)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &digimonType, statusCode, nil
+}
+
+// GetFieldByID récupère un champ par son ID
+func (s *HTTPSource) GetFieldByID(ctx context.Context, id int) (*Field, int, error) {
+	return s.fetchField(ctx, fmt.Sprintf("%s/field/%d", s.baseURL, id))
+}
+
+// GetFieldByName récupère un champ par son nom
+func (s *HTTPSource) GetFieldByName(ctx context.Context, name string) (*Field, int, error) {
+	return s.fetchField(ctx, fmt.Sprintf("%s/field/%s", s.baseURL, url.PathEscape(name)))
+}
+
+func (s *HTTPSource) fetchField(ctx context.Context, endpoint string) (*Field, int, error) {
+	var field Field
+	statusCode, err := s.getJSON(ctx, endpoint, &field)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &field, statusCode, nil
+}
+
+// GetSkillByID récupère une compétence par son ID
+func (s *HTTPSource) GetSkillByID(ctx context.Context, id int) (*Skill, int, error) {
+	return s.fetchSkill(ctx, fmt.Sprintf("%s/skill/%d", s.baseURL, id))
+}
+
+// GetSkillByName récupère une compétence par son nom
+func (s *HTTPSource) GetSkillByName(ctx context.Context, name string) (*Skill, int, error) {
+	return s.fetchSkill(ctx, fmt.Sprintf("%s/skill/%s", s.baseURL, url.PathEscape(name)))
+}
+
+func (s *HTTPSource) fetchSkill(ctx context.Context, endpoint string) (*Skill, int, error) {
+	var skill Skill
+	statusCode, err := s.getJSON(ctx, endpoint, &skill)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &skill, statusCode, nil
+}
+
+// ListResources récupère une page d'un type de ressources (level, attribute, type, field)
+func (s *HTTPSource) ListResources(ctx context.Context, kind string, page, pageSize int) (*ResourceListResponse, int, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Add("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		query.Add("pageSize", strconv.Itoa(pageSize))
+	}
+
+	endpoint := fmt.Sprintf("%s/%s", s.baseURL, url.PathEscape(kind))
+	if encoded := query.Encode(); encoded != "" {
+		endpoint += "?" + encoded
+	}
+
+	var list ResourceListResponse
+	statusCode, err := s.getJSON(ctx, endpoint, &list)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &list, statusCode, nil
+}
+
+// getJSON exécute un GET sur l'URL et décode la réponse JSON dans out,
+// en réessayant selon la politique de la source en cas d'erreur transitoire.
+// Tant que le disjoncteur est ouvert, l'appel échoue immédiatement.
+// Les échecs sont retournés sous forme d'*UpstreamError classée
+// (ErrNotFound, ErrTimeout...), accompagnée du code HTTP reçu de l'API
+// (500 sans réponse).
+func (s *HTTPSource) getJSON(ctx context.Context, endpoint string, out interface{}) (int, error) {
+	if err := s.breaker.Allow(); err != nil {
+		return upstreamStatus(err), err
+	}
+
+	statusCode, err := s.retry.withRetry(ctx, endpoint, func() (int, error) {
+		return s.fetchJSON(ctx, endpoint, out)
+	})
+	s.breaker.Record(err)
+	return statusCode, err
+}
+
+// fetchJSON exécute une seule tentative de GET, après avoir attendu son
+// tour auprès du limiteur de débit
+func (s *HTTPSource) fetchJSON(ctx context.Context, endpoint string, out interface{}) (int, error) {
+	if err := s.limiter.Wait(ctx); err != nil {
+		return upstreamStatus(err), err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return http.StatusInternalServerError,
+			fmt.Errorf("erreur création requête: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		err = &UpstreamError{Kind: transportKind(err), Endpoint: endpoint, Err: err}
+		return upstreamStatus(err), err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := &UpstreamError{
+			Kind:       statusKind(resp.StatusCode),
+			StatusCode: resp.StatusCode,
+			Endpoint:   endpoint,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+		return upstreamStatus(err), err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		err := &UpstreamError{Kind: ErrDecode, StatusCode: resp.StatusCode, Endpoint: endpoint, Err: err}
+		return upstreamStatus(err), err
+	}
+
+	return resp.StatusCode, nil
+}
+
+// parseRetryAfter lit un en-tête Retry-After exprimé en secondes ou en date HTTP
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// Stats retourne les compteurs du limiteur et l'état du disjoncteur
+func (s *HTTPSource) Stats() map[string]interface{} {
+	return map[string]interface{}{
+		"limiter": s.limiter.Stats(),
+		"breaker": s.breaker.State(),
+	}
+}
+
+// SourceStats rassemble les compteurs disponibles d'une source et des
+// sources qu'elle enveloppe (cache, limiteur, disjoncteur)
+func SourceStats(source DigimonSource) map[string]interface{} {
+	stats := map[string]interface{}{}
+	for source != nil {
+		switch s := source.(type) {
+		case *CachedSource:
+			stats["cache"] = s.Stats()
+			source = s.next
+		case *HTTPSource:
+			for key, value := range s.Stats() {
+				stats[key] = value
+			}
+			source = nil
+		default:
+			source = nil
+		}
+	}
+	return stats
+}
+
+// query construit les paramètres de requête correspondant aux options.
+// Le tri n'est pas transmis : digi-api renvoie toujours les listes par ID,
+// il est donc appliqué par le catalogue local (voir cacheKey).
+func (opts *DigimonListOptions) query() url.Values {
+	q := url.Values{}
+	if opts == nil {
+		return q
+	}
+
+	if opts.Name != "" {
+		q.Add("name", opts.Name)
+	}
+	if opts.Exact {
+		q.Add("exact", "true")
+	}
+	if opts.Attribute != "" {
+		q.Add("attribute", opts.Attribute)
+	}
+	if opts.XAntibody != nil {
+		q.Add("xAntibody", strconv.FormatBool(*opts.XAntibody))
+	}
+	if opts.Level != "" {
+		q.Add("level", opts.Level)
+	}
+	if opts.Page > 0 {
+		q.Add("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PageSize > 0 {
+		q.Add("pageSize", strconv.Itoa(opts.PageSize))
+	}
+
+	return q
+}
+
+// cacheKey identifie une page de liste dans le cache : les paramètres de
+// requête, complétés du tri, pour que deux tris d'une même page ne
+// partagent jamais leur résultat
+func (opts *DigimonListOptions) cacheKey() string {
+	q := opts.query()
+	if opts != nil && !opts.Sort.IsZero() {
+		q.Set("sort", opts.Sort.Field)
+		q.Set("dir", opts.Sort.Dir())
+	}
+	return "digimon?" + q.Encode()
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// SUIVI DES DONNÉES PÉRIMÉES
+// ============================================================
+
+// staleTrackerKey identifie le suivi dans un contexte de requête
+type staleTrackerKey struct{}
+
+// staleTracker retient si une requête a été servie, au moins en partie,
+// avec des données périmées du cache
+type staleTracker struct {
+	mu    sync.Mutex
+	stale bool
+	since time.Time // Date de la plus ancienne donnée périmée utilisée
+}
+
+// WithStaleTracker prépare le contexte d'une requête pour signaler
+// l'utilisation de données périmées (voir StaleSince)
+func WithStaleTracker(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleTrackerKey{}, &staleTracker{})
+}
+
+// StaleSince indique si des données périmées ont été servies pendant la
+// requête et, si oui, la date de la plus ancienne
+func StaleSince(ctx context.Context) (time.Time, bool) {
+	tracker, ok := ctx.Value(staleTrackerKey{}).(*staleTracker)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return tracker.since, tracker.stale
+}
+
+// markStale signale qu'une donnée enregistrée à storedAt a été servie périmée
+func markStale(ctx context.Context, storedAt time.Time) {
+	tracker, ok := ctx.Value(staleTrackerKey{}).(*staleTracker)
+	if !ok {
+		return
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if !tracker.stale || storedAt.Before(tracker.since) {
+		tracker.since = storedAt
+	}
+	tracker.stale = true
+}
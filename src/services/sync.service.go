@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SnapshotVersion est la version du format de snapshot écrit sur disque.
+// Elle doit être incrémentée à chaque changement incompatible de Digimon.
+const SnapshotVersion = 2
+
+// ============================================================
+// SNAPSHOT DU CATALOGUE
+// ============================================================
+
+// Snapshot est une copie locale et complète du catalogue Digimon
+type Snapshot struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Source      string    `json:"source,omitempty"`
+	Digimons    []Digimon `json:"digimons"`
+
+	indexOnce  sync.Once
+	byID       map[int]int // ID -> position dans Digimons
+	searchOnce sync.Once
+	search     *SearchIndex // Index des noms (voir SearchIndex)
+}
+
+// Digimon retourne le Digimon d'ID donné (index construit au premier appel)
+func (s *Snapshot) Digimon(id int) (*Digimon, bool) {
+	s.indexOnce.Do(func() {
+		s.byID = make(map[int]int, len(s.Digimons))
+		for i := range s.Digimons {
+			s.byID[s.Digimons[i].ID] = i
+		}
+	})
+
+	i, ok := s.byID[id]
+	if !ok {
+		return nil, false
+	}
+	return &s.Digimons[i], true
+}
+
+// LoadSnapshot lit un snapshot depuis le disque et vérifie sa version
+func LoadSnapshot(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("erreur ouverture snapshot: %w", err)
+	}
+	defer file.Close()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(file).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("erreur décodage snapshot: %w", err)
+	}
+
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("version de snapshot %d non supportée (attendue: %d)",
+			snapshot.Version, SnapshotVersion)
+	}
+
+	return &snapshot, nil
+}
+
+// WriteSnapshot écrit le snapshot sur disque de façon atomique
+// (fichier temporaire puis renommage)
+func WriteSnapshot(path string, snapshot *Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("erreur création dossier snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*.json")
+	if err != nil {
+		return fmt.Errorf("erreur création fichier temporaire: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(snapshot); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erreur encodage snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erreur écriture snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("erreur renommage snapshot: %w", err)
+	}
+	return nil
+}
+
+// ============================================================
+// SYNCHRONISATION
+// ============================================================
+
+// SyncOptions contient les paramètres de synchronisation du catalogue
+type SyncOptions struct {
+	PageSize    int    // Taille des pages de liste (défaut: 100)
+	Concurrency int    // Nombre de Digimons récupérés en parallèle (défaut: 8)
+	Source      string // Description de la source, recopiée dans le snapshot
+}
+
+// SyncCatalog parcourt toutes les pages de la liste puis récupère chaque
+// Digimon complet avec une concurrence bornée. La synchronisation échoue
+// dès qu'un Digimon ne peut pas être récupéré, pour ne jamais produire
+// un snapshot incomplet.
+func SyncCatalog(ctx context.Context, source DigimonSource, opts SyncOptions) (*Snapshot, error) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 100
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+
+	// 1. Liste de tous les résumés, page par page
+	summaries := []DigimonSummary{}
+	listOpts := &DigimonListOptions{PageSize: opts.PageSize}
+	for summary, err := range IterateDigimons(ctx, source, listOpts, true) {
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	log.Printf("Sync - %d Digimons listés", len(summaries))
+
+	// 2. Récupération des Digimons complets
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	digimons := make([]Digimon, len(summaries))
+	semaphore := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	for i, summary := range summaries {
+		wg.Add(1)
+		go func(i int, summary DigimonSummary) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				return
+			}
+
+			digimon, _, err := source.GetDigimonByID(ctx, summary.ID)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("erreur Digimon %d (%s): %w", summary.ID, summary.Name, err)
+					cancel()
+				})
+				return
+			}
+			digimons[i] = *digimon
+		}(i, summary)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(digimons, func(i, j int) bool { return digimons[i].ID < digimons[j].ID })
+	log.Printf("Sync - %d Digimons récupérés", len(digimons))
+
+	return &Snapshot{
+		Version:     SnapshotVersion,
+		GeneratedAt: time.Now().UTC(),
+		Source:      opts.Source,
+		Digimons:    digimons,
+	}, nil
+}
+
+// ============================================================
+// STOCKAGE DU CATALOGUE
+// ============================================================
+
+// CatalogStore conserve le snapshot courant en mémoire et sur disque.
+// Il peut être rechargé ou resynchronisé à chaud.
+type CatalogStore struct {
+	path     string
+	mu       sync.RWMutex
+	snapshot *Snapshot
+}
+
+// NewCatalogStore crée un stockage lié au fichier de snapshot donné
+func NewCatalogStore(path string) *CatalogStore {
+	return &CatalogStore{path: path}
+}
+
+// Path retourne le chemin du fichier de snapshot
+func (c *CatalogStore) Path() string {
+	return c.path
+}
+
+// Load charge le snapshot depuis le disque
+func (c *CatalogStore) Load() error {
+	snapshot, err := LoadSnapshot(c.path)
+	if err != nil {
+		return err
+	}
+	c.set(snapshot)
+	return nil
+}
+
+// Sync synchronise le catalogue depuis la source, l'écrit sur disque
+// puis remplace le snapshot en mémoire
+func (c *CatalogStore) Sync(ctx context.Context, source DigimonSource, opts SyncOptions) error {
+	snapshot, err := SyncCatalog(ctx, source, opts)
+	if err != nil {
+		return err
+	}
+	if err := WriteSnapshot(c.path, snapshot); err != nil {
+		return err
+	}
+	c.set(snapshot)
+	return nil
+}
+
+// Snapshot retourne le snapshot courant (false s'il n'est pas encore chargé)
+func (c *CatalogStore) Snapshot() (*Snapshot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot, c.snapshot != nil
+}
+
+func (c *CatalogStore) set(snapshot *Snapshot) {
+	c.mu.Lock()
+	c.snapshot = snapshot
+	c.mu.Unlock()
+}
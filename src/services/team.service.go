@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"guide/battle"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxTeamSize est le nombre maximum de Digimons dans une équipe
+const MaxTeamSize = 6
+
+// Statuts d'une équipe face à un attribut adverse (AttributeMatchup.Status)
+const (
+	MatchupWeak    = "faiblesse" // Plus de membres désavantagés qu'avantagés
+	MatchupStrong  = "avantage"  // Plus de membres avantagés que désavantagés
+	MatchupNeutral = "neutre"
+)
+
+// ============================================================
+// ÉQUIPE
+// ============================================================
+
+// Team est une équipe : un nom et la liste ordonnée des IDs de ses Digimons.
+// Elle est entièrement décrite par son URL (voir Query), ce qui permet de la
+// partager sans la stocker côté serveur.
+type Team struct {
+	Name string `json:"name"`
+	IDs  []int  `json:"ids"`
+}
+
+// ParseTeam lit une équipe depuis son nom et sa liste d'IDs ("1,2,3").
+// Les doublons sont ignorés ; au plus MaxTeamSize Digimons sont acceptés.
+func ParseTeam(name, ids string) (Team, error) {
+	team := Team{Name: strings.TrimSpace(name), IDs: []int{}}
+
+	for _, part := range strings.Split(ids, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id <= 0 {
+			return Team{}, fmt.Errorf("ID invalide : %q", part)
+		}
+		team = team.With(id)
+	}
+
+	if len(team.IDs) > MaxTeamSize {
+		return Team{}, fmt.Errorf("une équipe compte au plus %d Digimons", MaxTeamSize)
+	}
+	return team, nil
+}
+
+// With retourne l'équipe avec le Digimon ajouté (inchangée s'il y est déjà)
+func (t Team) With(id int) Team {
+	if slices.Contains(t.IDs, id) {
+		return t
+	}
+	t.IDs = append(slices.Clone(t.IDs), id)
+	return t
+}
+
+// Without retourne l'équipe sans le Digimon donné
+func (t Team) Without(id int) Team {
+	t.IDs = slices.DeleteFunc(slices.Clone(t.IDs), func(member int) bool { return member == id })
+	return t
+}
+
+// IsFull indique si l'équipe a atteint MaxTeamSize
+func (t Team) IsFull() bool {
+	return len(t.IDs) >= MaxTeamSize
+}
+
+// IDList retourne les IDs de l'équipe séparés par des virgules ("1,2,3")
+func (t Team) IDList() string {
+	ids := make([]string, len(t.IDs))
+	for i, id := range t.IDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return strings.Join(ids, ",")
+}
+
+// Query encode l'équipe en paramètres d'URL (name=...&ids=1,2,3)
+func (t Team) Query() string {
+	query := url.Values{}
+	if t.Name != "" {
+		query.Set("name", t.Name)
+	}
+	query.Set("ids", t.IDList())
+	return query.Encode()
+}
+
+// ============================================================
+// ANALYSE DE COMPOSITION
+// ============================================================
+
+// TeamCount compte les membres partageant une valeur (attribut, niveau...)
+type TeamCount struct {
+	Value   string   `json:"value"`
+	Count   int      `json:"count"`
+	Members []string `json:"members"`
+}
+
+// AttributeMatchup décrit l'équipe face à un attribut adverse : membres qui
+// subissent l'avantage de l'adversaire et membres qui l'exploitent
+type AttributeMatchup struct {
+	Attribute string   `json:"attribute"`
+	Weak      []string `json:"weak"`
+	Strong    []string `json:"strong"`
+	Status    string   `json:"status"`
+}
+
+// TeamAnalysis est l'analyse de composition d'une équipe
+type TeamAnalysis struct {
+	Team       Team               `json:"team"`
+	Members    []DigimonCard      `json:"members"`
+	Attributes []TeamCount        `json:"attributes"` // Couverture des attributs
+	Levels     []TeamCount        `json:"levels"`     // Répartition des niveaux
+	Fields     []TeamCount        `json:"fields"`
+	Types      []TeamCount        `json:"types"`
+	Matchups   []AttributeMatchup `json:"matchups"` // Face à chaque attribut adverse
+	Warnings   []string           `json:"warnings"`
+	Balanced   bool               `json:"balanced"`
+}
+
+// AnalyzeTeam récupère les Digimons de l'équipe en parallèle puis analyse sa
+// composition face aux attributs adverses donnés
+func AnalyzeTeam(ctx context.Context, source DigimonSource, team Team, attributes []string) (*TeamAnalysis, error) {
+	summaries := make([]DigimonSummary, len(team.IDs))
+	for i, id := range team.IDs {
+		summaries[i] = DigimonSummary{ID: id}
+	}
+
+	hydrated, _ := HydrateFrom(ctx, source, summaries, MaxTeamSize)
+
+	digimons := make([]*Digimon, len(hydrated))
+	for i, result := range hydrated {
+		if result.Err != nil {
+			return nil, fmt.Errorf("équipe - Digimon %d: %w", result.Summary.ID, result.Err)
+		}
+		digimons[i] = result.Digimon
+	}
+
+	return NewTeamAnalysis(team, digimons, attributes), nil
+}
+
+// NewTeamAnalysis analyse une équipe dont les Digimons sont déjà récupérés.
+// L'attribut d'un membre est son premier attribut, comme en combat.
+func NewTeamAnalysis(team Team, digimons []*Digimon, attributes []string) *TeamAnalysis {
+	analysis := &TeamAnalysis{
+		Team:     team,
+		Members:  make([]DigimonCard, len(digimons)),
+		Warnings: []string{},
+	}
+	for i, digimon := range digimons {
+		analysis.Members[i] = HydratedDigimon{Summary: digimon.Summary(""), Digimon: digimon}.Card()
+	}
+
+	facets := map[string]func(d *Digimon) []string{}
+	for _, f := range (DigimonQuery{}).facets() {
+		facets[f.name] = f.values
+	}
+	analysis.Attributes = teamCounts(digimons, facets[FacetAttributes], attributes)
+	analysis.Levels = teamCounts(digimons, facets[FacetLevels], nil)
+	analysis.Fields = teamCounts(digimons, facets[FacetFields], nil)
+	analysis.Types = teamCounts(digimons, facets[FacetTypes], nil)
+
+	for _, attribute := range attributes {
+		analysis.Matchups = append(analysis.Matchups, teamMatchup(digimons, attribute))
+	}
+
+	analysis.Warnings = teamWarnings(analysis, digimons)
+	analysis.Balanced = len(analysis.Warnings) == 0
+	return analysis
+}
+
+// teamCounts compte les membres par valeur. Les valeurs attendues sont
+// toujours listées (compteur nul compris), dans l'ordre fourni.
+func teamCounts(digimons []*Digimon, values func(d *Digimon) []string, expected []string) []TeamCount {
+	counts := make([]TeamCount, 0, len(expected))
+	for _, value := range expected {
+		counts = append(counts, TeamCount{Value: value, Members: []string{}})
+	}
+
+	for _, digimon := range digimons {
+		for _, value := range uniqueValues(values(digimon)) {
+			i := slices.IndexFunc(counts, func(c TeamCount) bool { return strings.EqualFold(c.Value, value) })
+			if i < 0 {
+				counts = append(counts, TeamCount{Value: value, Members: []string{}})
+				i = len(counts) - 1
+			}
+			counts[i].Count++
+			counts[i].Members = append(counts[i].Members, digimon.Name)
+		}
+	}
+	return counts
+}
+
+// teamMatchup évalue l'équipe face à un attribut adverse avec le triangle
+// des attributs du moteur de combat
+func teamMatchup(digimons []*Digimon, attribute string) AttributeMatchup {
+	matchup := AttributeMatchup{Attribute: attribute, Weak: []string{}, Strong: []string{}}
+
+	for _, digimon := range digimons {
+		own := ""
+		if len(digimon.Attributes) > 0 {
+			own = digimon.Attributes[0].Attribute
+		}
+		if battle.AttributeMultiplier(attribute, own) == battle.AdvantageMultiplier {
+			matchup.Weak = append(matchup.Weak, digimon.Name)
+		}
+		if battle.AttributeMultiplier(own, attribute) == battle.AdvantageMultiplier {
+			matchup.Strong = append(matchup.Strong, digimon.Name)
+		}
+	}
+
+	switch {
+	case len(matchup.Weak) > len(matchup.Strong):
+		matchup.Status = MatchupWeak
+	case len(matchup.Strong) > len(matchup.Weak):
+		matchup.Status = MatchupStrong
+	default:
+		matchup.Status = MatchupNeutral
+	}
+	return matchup
+}
+
+// teamWarnings liste les déséquilibres de l'équipe
+func teamWarnings(analysis *TeamAnalysis, digimons []*Digimon) []string {
+	warnings := []string{}
+	if len(digimons) == 0 {
+		return append(warnings, "L'équipe est vide")
+	}
+
+	for _, matchup := range analysis.Matchups {
+		if matchup.Status == MatchupWeak && len(matchup.Strong) == 0 {
+			warnings = append(warnings, fmt.Sprintf("Vulnérable face à %s : %d membre(s) désavantagé(s), aucun pour contrer",
+				matchup.Attribute, len(matchup.Weak)))
+		}
+	}
+
+	for _, count := range analysis.Attributes {
+		if count.Count == len(digimons) && len(digimons) > 1 {
+			warnings = append(warnings, fmt.Sprintf("Tous les membres sont %s", count.Value))
+		}
+	}
+
+	lowest, highest := "", ""
+	for _, digimon := range digimons {
+		for _, level := range digimon.Levels {
+			if lowest == "" || battle.LevelTier(level.Level) < battle.LevelTier(lowest) {
+				lowest = level.Level
+			}
+			if highest == "" || battle.LevelTier(level.Level) > battle.LevelTier(highest) {
+				highest = level.Level
+			}
+		}
+	}
+	if lowest != "" && battle.LevelTier(highest)-battle.LevelTier(lowest) >= 3 {
+		warnings = append(warnings, fmt.Sprintf("Écart de niveaux important (%s à %s)", lowest, highest))
+	}
+
+	if len(digimons) >= 3 && len(analysis.Fields) == 1 {
+		warnings = append(warnings, fmt.Sprintf("Tous les membres appartiennent au champ %s", analysis.Fields[0].Value))
+	}
+
+	return warnings
+}
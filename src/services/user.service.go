@@ -0,0 +1,238 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// UserVersion est la version du format du fichier des utilisateurs
+const UserVersion = 1
+
+// Contraintes des comptes
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 128
+)
+
+// usernamePattern décrit les noms d'utilisateur acceptés (3 à 32 caractères)
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_.-]{3,32}$`)
+
+// ============================================================
+// UTILISATEURS
+// ============================================================
+
+// User est un compte utilisateur, tel qu'exposé au reste de l'application
+// (sans l'empreinte du mot de passe)
+type User struct {
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// userRecord est un compte tel qu'enregistré sur disque
+type userRecord struct {
+	User
+	PasswordHash string `json:"passwordHash"`
+}
+
+// userFile est le contenu du fichier des utilisateurs
+type userFile struct {
+	Version int          `json:"version"`
+	Users   []userRecord `json:"users"`
+}
+
+// ============================================================
+// STOCKAGE DES UTILISATEURS
+// ============================================================
+
+// UserStore conserve les comptes en mémoire et dans un fichier JSON, réécrit
+// de façon atomique à chaque inscription
+type UserStore struct {
+	path  string
+	mu    sync.RWMutex
+	users []userRecord // Par ID croissant
+
+	// dummyHash sert à vérifier un mot de passe pour un compte inconnu, afin
+	// que la durée d'une connexion ne révèle pas l'existence du compte
+	dummyOnce sync.Once
+	dummyHash string
+}
+
+// NewUserStore crée un stockage vide lié au fichier donné
+func NewUserStore(path string) *UserStore {
+	return &UserStore{path: path}
+}
+
+// Path retourne le chemin du fichier des utilisateurs
+func (s *UserStore) Path() string {
+	return s.path
+}
+
+// Load charge les comptes depuis le disque. Un fichier absent n'est pas une
+// erreur : il sera créé à la première inscription.
+func (s *UserStore) Load() error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erreur ouverture utilisateurs: %w", err)
+	}
+	defer file.Close()
+
+	var content userFile
+	if err := json.NewDecoder(file).Decode(&content); err != nil {
+		return fmt.Errorf("erreur décodage utilisateurs: %w", err)
+	}
+	if content.Version != UserVersion {
+		return fmt.Errorf("version d'utilisateurs %d non supportée (attendue: %d)",
+			content.Version, UserVersion)
+	}
+
+	slices.SortFunc(content.Users, func(a, b userRecord) int { return a.ID - b.ID })
+
+	s.mu.Lock()
+	s.users = content.Users
+	s.mu.Unlock()
+	return nil
+}
+
+// Get retourne le compte d'ID donné
+func (s *UserStore) Get(id int) (*User, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i, found := slices.BinarySearchFunc(s.users, id, func(record userRecord, id int) int { return record.ID - id })
+	if !found {
+		return nil, false
+	}
+	user := s.users[i].User
+	return &user, true
+}
+
+// Register crée un compte. Le nom d'utilisateur est ramené en minuscules et
+// doit être unique.
+func (s *UserStore) Register(username, password string) (*User, error) {
+	username = normalizeUsername(username)
+	if !usernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("%w : le nom d'utilisateur doit compter 3 à 32 caractères "+
+			"parmi lettres minuscules, chiffres, « _ », « . » et « - »", ErrInvalidInput)
+	}
+	if length := utf8.RuneCountInString(password); length < MinPasswordLength || length > MaxPasswordLength {
+		return nil, fmt.Errorf("%w : le mot de passe doit compter %d à %d caractères",
+			ErrInvalidInput, MinPasswordLength, MaxPasswordLength)
+	}
+
+	// Hachage hors verrou : il est volontairement lent
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(username) >= 0 {
+		return nil, fmt.Errorf("%w : le nom d'utilisateur %q est déjà pris", ErrConflict, username)
+	}
+
+	id := 1
+	if len(s.users) > 0 {
+		id = s.users[len(s.users)-1].ID + 1
+	}
+	record := userRecord{
+		User:         User{ID: id, Username: username, CreatedAt: time.Now().UTC()},
+		PasswordHash: hash,
+	}
+
+	users := append(slices.Clip(s.users), record)
+	if err := writeUsers(s.path, users); err != nil {
+		return nil, err
+	}
+	s.users = users
+
+	user := record.User
+	return &user, nil
+}
+
+// Authenticate vérifie les identifiants et retourne le compte correspondant.
+// Un nom inconnu et un mot de passe erroné donnent la même erreur.
+func (s *UserStore) Authenticate(username, password string) (*User, error) {
+	username = normalizeUsername(username)
+
+	s.mu.RLock()
+	var record *userRecord
+	if i := s.find(username); i >= 0 {
+		found := s.users[i]
+		record = &found
+	}
+	s.mu.RUnlock()
+
+	if record == nil {
+		VerifyPassword(s.dummy(), password)
+		return nil, fmt.Errorf("%w : identifiants incorrects", ErrUnauthorized)
+	}
+	if !VerifyPassword(record.PasswordHash, password) {
+		return nil, fmt.Errorf("%w : identifiants incorrects", ErrUnauthorized)
+	}
+
+	user := record.User
+	return &user, nil
+}
+
+// find retourne la position du compte de nom donné (-1 si absent).
+// Le verrou doit être tenu par l'appelant.
+func (s *UserStore) find(username string) int {
+	return slices.IndexFunc(s.users, func(record userRecord) bool { return record.Username == username })
+}
+
+// dummy retourne une empreinte de référence, calculée au premier besoin
+func (s *UserStore) dummy() string {
+	s.dummyOnce.Do(func() {
+		s.dummyHash, _ = HashPassword(randomToken(16))
+	})
+	return s.dummyHash
+}
+
+// normalizeUsername ramène un nom d'utilisateur à sa forme enregistrée
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// writeUsers écrit les comptes sur disque de façon atomique (fichier
+// temporaire puis renommage), lisible uniquement par son propriétaire
+func writeUsers(path string, users []userRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("erreur création dossier utilisateurs: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".users-*.json")
+	if err != nil {
+		return fmt.Errorf("erreur création fichier temporaire: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	encoder := json.NewEncoder(tmp)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(userFile{Version: UserVersion, Users: users}); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erreur encodage utilisateurs: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erreur écriture utilisateurs: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("erreur renommage utilisateurs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestUserAuthenticate(t *testing.T) {
+	store := NewUserStore(filepath.Join(t.TempDir(), "users.json"))
+	registered, err := store.Register("Tai", "agumon-42")
+	if err != nil {
+		t.Fatalf("erreur inattendue: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{name: "identifiants corrects", username: "tai", password: "agumon-42"},
+		{name: "nom en majuscules", username: "TAI", password: "agumon-42"},
+		{name: "mauvais mot de passe", username: "tai", password: "agumon-43", wantErr: ErrUnauthorized},
+		{name: "utilisateur inconnu", username: "matt", password: "agumon-42", wantErr: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := store.Authenticate(tt.username, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("erreur = %v, attendu %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && user.ID != registered.ID {
+				t.Errorf("utilisateur = %+v, attendu %+v", user, registered)
+			}
+		})
+	}
+
+	// Le compte survit au rechargement du fichier
+	reloaded := NewUserStore(store.Path())
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("rechargement: %v", err)
+	}
+	if _, err := reloaded.Authenticate("tai", "agumon-42"); err != nil {
+		t.Errorf("après rechargement: %v", err)
+	}
+}
+
+func TestUserRegisterConflict(t *testing.T) {
+	store := NewUserStore(filepath.Join(t.TempDir(), "users.json"))
+	if _, err := store.Register("tai", "agumon-42"); err != nil {
+		t.Fatalf("erreur inattendue: %v", err)
+	}
+	if _, err := store.Register("TAI", "gabumon-42"); !errors.Is(err, ErrConflict) {
+		t.Errorf("erreur = %v, attendu ErrConflict", err)
+	}
+}